@@ -1,34 +1,154 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/javaruntime"
 	"github.com/abulleDev/mcserverdl/v2/pkg/factory"
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+	mcprovider "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/declarative"
+	"github.com/abulleDev/mcserverdl/v2/pkg/serverconfig"
+	"github.com/abulleDev/mcserverdl/v2/pkg/startscript"
 )
 
+// version is the version of mcserverdl, recorded in the lock files it writes.
+const version = "v2.2.2"
+
 func main() {
 	// Initialize a logger that writes to stdout without timestamps/prefixes.
 	logger := log.New(os.Stdout, "", 0)
 
+	// Register the declarative provider definitions before the flags are defined,
+	// so that the server types they add appear in the help text.
+	// The MCSERVERDL_PROVIDERS_DIR environment variable overrides the default directory.
+	providersDir := os.Getenv("MCSERVERDL_PROVIDERS_DIR")
+	if providersDir == "" {
+		providersDir, _ = declarative.DefaultDir()
+	}
+	if providersDir != "" {
+		if err := declarative.RegisterDir(providersDir); err != nil {
+			logger.Printf("Warning: failed to load provider definitions: %v", err)
+		}
+	}
+
+	// Dispatch the subcommands with their own flags.
+	if len(os.Args) > 1 && os.Args[1] == "update" {
+		runUpdate(os.Args[2:], logger)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		runVerify(os.Args[2:], logger)
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "players" {
+		runPlayers(os.Args[2:], logger)
+		return
+	}
+
 	// Define command-line flags for server configuration.
-	serverType := flag.String("type", "", "Server type (vanilla, paper, forge, fabric, neoforge, purpur)")
+	serverType := flag.String("type", "", "Server type ("+strings.Join(factory.Names(), ", ")+")")
 	gameVersion := flag.String("game", "", "Game version (e.g., 1.21.6, 1.13-pre7, 25w14craftmine)")
 	serverVersion := flag.String("server", "", "Loader/build version (default latest)")
 	path := flag.String("path", "./", "Download path for the server jar")
 	showVersion := flag.Bool("version", false, "Print the current version")
+	listTypes := flag.Bool("list", false, "List the supported server types")
+	nativeInstall := flag.Bool("native-install", false, "Install Forge/NeoForge servers natively instead of leaving the installer step to you")
+	extractBundler := flag.Bool("extract-bundler", false, "Unpack the vanilla bundler server jar into versions/ and libraries/")
+	fabricInstaller := flag.String("fabric-installer", "", "The Fabric installer version used to build the server launcher (default: latest)")
+	variant := flag.String("variant", "", "The download variant of the server jar (e.g., 'mojmap' for Paper)")
+	patchPaperclip := flag.Bool("patch-paperclip", false, "Apply the Paperclip patches of Paper servers so that they start offline")
+	runInstaller := flag.Bool("install", false, "Run the Forge/NeoForge installer after the download")
+	javaPath := flag.String("java", "", "Java executable used for installer steps and the Java version check (default from JAVA_HOME or PATH)")
+	withJava := flag.Bool("with-java", false, "Download a Java runtime matching the server into the runtime/ directory of the download path")
+	javaSource := flag.String("java-source", javaruntime.SourceMojang, "Where -with-java downloads the runtime from (mojang, adoptium)")
+	adoptiumURL := flag.String("adoptium-url", javaruntime.AdoptiumURL, "Base URL of the Adoptium API used by -java-source adoptium")
+	startScript := flag.Bool("start-script", false, "Generate start.sh and start.bat for the server")
+	memory := flag.String("memory", "", "Heap size of the start scripts (e.g., 4G); default is Java's default")
+	jvmFlags := flag.String("flags", startscript.PresetNone, "JVM flag preset of the start scripts ("+strings.Join(startscript.Presets(), ", ")+")")
+	restart := flag.Bool("restart", false, "Make the start scripts restart the server after a crash")
+	acceptEULA := flag.Bool("accept-eula", false, "Accept the Minecraft EULA ("+serverconfig.EULAURL+") by writing eula.txt")
+	propertiesTemplate := flag.String("properties-template", "", "A server.properties file whose values seed the server's server.properties")
+	port := flag.Int("port", 25565, "Set server-port in server.properties")
+	motd := flag.String("motd", "", "Set motd in server.properties")
+	onlineMode := flag.Bool("online-mode", true, "Set online-mode in server.properties")
+	viewDistance := flag.Int("view-distance", 10, "Set view-distance in server.properties")
+	maxPlayers := flag.Int("max-players", 20, "Set max-players in server.properties")
+	difficulty := flag.String("difficulty", "", "Set difficulty in server.properties (peaceful, easy, normal, hard)")
+	gamemode := flag.String("gamemode", "", "Set gamemode in server.properties (survival, creative, adventure, spectator)")
+	levelName := flag.String("level-name", "", "Set level-name in server.properties")
+	levelSeed := flag.String("level-seed", "", "Set level-seed in server.properties")
+	var properties propertyFlags
+	flag.Var(&properties, "property", "Set any key in server.properties as key=value (repeatable)")
+	var players playerListFlags
+	players.register(flag.CommandLine)
+	profileURL := flag.String("profile-url", serverconfig.MojangProfileURL, "Endpoint resolving player names to UUIDs in online mode (e.g., a local stand-in)")
+	lockPath := flag.String("lock", "", "Install exactly what a "+lockfile.FileName+" records, failing if the downloaded files differ")
+	javaCheck := flag.String("java-check", "warn", "What to do when the installed Java is too old for the server (warn, fail, off)")
 
-	// Parse the provided command-line flags.
-	flag.Parse()
+	// Parse the provided command-line flags. "install" may be given as a subcommand, which is the default.
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "install" {
+		args = args[1:]
+	}
+	flag.CommandLine.Parse(args)
 
 	// If version flag is set, print version and exit.
 	if *showVersion {
-		logger.Println("mcserverdl v2.2.2")
+		logger.Println("mcserverdl " + version)
 		return
 	}
 
+	// If list flag is set, print the registered server types and exit.
+	if *listTypes {
+		for _, info := range factory.List() {
+			line := fmt.Sprintf("%-14s %s", info.Name, info.DisplayName)
+			if len(info.Aliases) > 0 {
+				line += fmt.Sprintf(" (aliases: %s)", strings.Join(info.Aliases, ", "))
+			}
+			if info.Homepage != "" {
+				line += " - " + info.Homepage
+			}
+			logger.Println(line)
+		}
+		return
+	}
+
+	// Take the server and the install options from the lock file, which replaces their flags.
+	var installLock *lockfile.Lock
+	if *lockPath != "" {
+		lock, err := lockfile.Read(*lockPath)
+		if err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "type", "game", "server", "variant", "fabric-installer", "extract-bundler", "patch-paperclip", "native-install", "install", "with-java", "java-source":
+				logger.Fatalf("Error: -%s cannot be combined with -lock", f.Name)
+			}
+		})
+
+		installLock = lock
+		*serverType, *gameVersion, *serverVersion = lock.Provider, lock.GameVersion, lock.ServerVersion
+		*variant, *fabricInstaller = lock.Options.Variant, lock.Options.InstallerVersion
+		*extractBundler, *patchPaperclip = lock.Options.ExtractBundler, lock.Options.PatchPaperclip
+		*nativeInstall, *runInstaller = lock.Options.NativeInstall, lock.Options.RunInstaller
+		if lock.Options.JavaSource != "" {
+			*withJava, *javaSource = true, lock.Options.JavaSource
+		}
+		logger.Printf("Installing %s %s %s from %s", lock.Provider, lock.GameVersion, lock.ServerVersion, *lockPath)
+	}
+
 	// Validate mandatory flags (type and game version).
 	if *serverType == "" || *gameVersion == "" {
 		logger.Println("Usage:")
@@ -36,6 +156,57 @@ func main() {
 		return
 	}
 
+	// Validate the Java check mode.
+	if *javaCheck != "warn" && *javaCheck != "fail" && *javaCheck != "off" {
+		logger.Fatalf("Error: invalid -java-check value '%s' (expected warn, fail or off)", *javaCheck)
+	}
+
+	// Validate the start script options.
+	scriptOptions := startscript.Options{Memory: *memory, Preset: *jvmFlags, Restart: *restart}
+	if err := scriptOptions.Validate(); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Collect the server.properties values of the flags that were given explicitly,
+	// so that the defaults shown in the help text don't overwrite existing values.
+	settings := serverconfig.Settings{Extra: properties}
+	writeProperties := *propertiesTemplate != "" || len(properties) > 0
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			settings.ServerPort = port
+		case "motd":
+			settings.MOTD = motd
+		case "online-mode":
+			settings.OnlineMode = onlineMode
+		case "view-distance":
+			settings.ViewDistance = viewDistance
+		case "max-players":
+			settings.MaxPlayers = maxPlayers
+		case "difficulty":
+			settings.Difficulty = difficulty
+		case "gamemode":
+			settings.Gamemode = gamemode
+		case "level-name":
+			settings.LevelName = levelName
+		case "level-seed":
+			settings.LevelSeed = levelSeed
+		default:
+			return
+		}
+		writeProperties = true
+	})
+
+	// Validate the op level before anything is downloaded.
+	if players.opLevel < 1 || players.opLevel > 4 {
+		logger.Fatalf("Error: invalid -op-level %d (expected 1 to 4)", players.opLevel)
+	}
+
+	// Reject invalid property values (e.g., a port out of range) before anything is downloaded.
+	if err := settings.Validate(); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
 	// Validate the download path.
 	// We check if the path exists and ensure it is not a file.
 	info, err := os.Stat(*path)
@@ -54,23 +225,135 @@ func main() {
 	if err != nil {
 		logger.Fatalf("Error: %v", err)
 	}
+	providerInfo, _ := factory.Lookup(*serverType)
 
 	// Set the logger for the provider to allow consistent logging.
 	provider.SetLogger(logger)
 
-	// If server version is not provided, automatically fetch the latest version.
-	// Note: Vanilla is excluded here as its logic is handled differently (usually 1:1 with game version).
-	if *serverVersion == "" && *serverType != "vanilla" {
+	// Only Java edition servers ask for the EULA to be accepted in eula.txt.
+	javaEdition := providerInfo.Edition == factory.JavaEdition
+	if *acceptEULA && !javaEdition {
+		logger.Fatalf("Error: %s has no eula.txt to accept", providerInfo.DisplayName)
+	}
+
+	// The player list files are those of Java edition servers as well.
+	if !players.empty() && !javaEdition {
+		logger.Fatalf("Error: %s doesn't use ops.json, whitelist.json or the ban lists", providerInfo.DisplayName)
+	}
+
+	// Start scripts launch Java, so they are only available for Java edition servers.
+	if *startScript && !javaEdition {
+		logger.Fatalf("Error: %s does not run on Java, so no start scripts can be generated", providerInfo.DisplayName)
+	}
+
+	// Unpack the bundler server jar for providers that download one.
+	if *extractBundler {
+		extractor, ok := provider.(mcprovider.BundlerExtractor)
+		if !ok {
+			logger.Fatalf("Error: %s does not support bundler extraction", providerInfo.DisplayName)
+		}
+		extractor.SetExtractBundler(true)
+	}
+
+	// Pin the installer version for providers that build the server launcher with an installer.
+	if *fabricInstaller != "" {
+		selector, ok := provider.(mcprovider.InstallerVersionSelector)
+		if !ok {
+			logger.Fatalf("Error: %s does not use a Fabric installer", providerInfo.DisplayName)
+		}
+		selector.SetInstallerVersion(*fabricInstaller)
+	}
+
+	// Select the download variant for providers that publish several jars per build.
+	if *variant != "" {
+		selector, ok := provider.(mcprovider.VariantSelector)
+		if !ok {
+			logger.Fatalf("Error: %s does not have download variants", providerInfo.DisplayName)
+		}
+		selector.SetVariant(*variant)
+	}
+
+	// Apply the Paperclip patches for providers that download a Paperclip launcher.
+	if *patchPaperclip {
+		patcher, ok := provider.(mcprovider.PaperclipPatcher)
+		if !ok {
+			logger.Fatalf("Error: %s does not support Paperclip patching", providerInfo.DisplayName)
+		}
+		patcher.SetPatchPaperclip(true)
+	}
+
+	// If server version is not provided, prefer the recommended version of providers that publish one (e.g., Forge).
+	if recommender, ok := provider.(mcprovider.RecommendedServerVersioner); ok && *serverVersion == "" {
+		recommended, err := recommender.RecommendedServerVersion(*gameVersion)
+		if err != nil {
+			logger.Printf("Warning: failed to fetch the recommended %s server version: %v", providerInfo.DisplayName, err)
+		} else if recommended != "" {
+			*serverVersion = recommended
+			logger.Printf("Recommended %s server version is %s", providerInfo.DisplayName, *serverVersion)
+		}
+	}
+
+	// Otherwise, automatically fetch the latest version.
+	// Note: Types without server versions (e.g., Vanilla) are excluded here as their version is 1:1 with the game version.
+	if *serverVersion == "" && providerInfo.HasServerVersions {
 		logger.Printf("No server version specified, fetching the latest for %s...", *gameVersion)
 		serverVersions, err := provider.ServerVersions(*gameVersion)
 		if err != nil {
-			logger.Fatalf("Error fetching latest %s server version: %v", *serverType, err)
+			logger.Fatalf("Error fetching latest %s server version: %v", providerInfo.DisplayName, err)
 		}
 		if len(serverVersions) == 0 {
 			logger.Fatalf("Error: No server versions found for game version %s", *gameVersion)
 		}
 		*serverVersion = serverVersions[0]
-		logger.Printf("Latest %s server version is %s", *serverType, *serverVersion)
+		logger.Printf("Latest %s server version is %s", providerInfo.DisplayName, *serverVersion)
+	}
+
+	// Provision a Java runtime matching the server, or check that the installed Java satisfies the requirement.
+	// A lock file pins the major version it recorded.
+	runtimeSource, runtimeVersion := "", 0
+	if *withJava {
+		requirer, ok := provider.(mcprovider.JavaVersionRequirer)
+		if !ok {
+			logger.Fatalf("Error: %s does not run on Java", providerInfo.DisplayName)
+		}
+		options := javaruntime.Options{
+			Source:      *javaSource,
+			AdoptiumURL: *adoptiumURL,
+			Log:         logger.Printf,
+		}
+		if installLock != nil {
+			options.MajorVersion = installLock.Options.JavaVersion
+		}
+		java, err := provisionJava(requirer, *gameVersion, *serverVersion, *path, &options)
+		if err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		*javaPath = java
+		runtimeSource, runtimeVersion = *javaSource, options.MajorVersion
+		logger.Printf("Using the Java runtime %s", java)
+	} else if requirer, ok := provider.(mcprovider.JavaVersionRequirer); ok && *javaCheck != "off" {
+		if err := checkJavaVersion(requirer, *gameVersion, *serverVersion, *javaPath, logger); err != nil {
+			if *javaCheck == "fail" {
+				logger.Fatalf("Error: %v", err)
+			}
+			logger.Printf("Warning: %v", err)
+		}
+	}
+
+	// Enable the native installation for providers that normally require a Java installer.
+	if *nativeInstall {
+		installer, ok := provider.(mcprovider.NativeInstaller)
+		if !ok {
+			logger.Fatalf("Error: %s does not support native installation", providerInfo.DisplayName)
+		}
+		installer.SetNativeInstall(true, *javaPath)
+	} else if *runInstaller {
+		// Run the downloaded installer for providers that ship one.
+		runner, ok := provider.(mcprovider.InstallerRunner)
+		if !ok {
+			logger.Fatalf("Error: %s does not have an installer to run", providerInfo.DisplayName)
+		}
+		runner.SetRunInstaller(true, *javaPath)
 	}
 
 	// Create the target directory if it doesn't exist.
@@ -78,22 +361,403 @@ func main() {
 		logger.Fatalf("Error: %v", err)
 	}
 
+	// Record the download URL, and the directory's files to find those the download creates or changes.
+	downloadURL, err := provider.DownloadURL(*gameVersion, *serverVersion)
+	if err != nil {
+		logger.Printf("Warning: failed to determine the download URL: %v", err)
+	}
+	before, err := lockfile.TakeSnapshot(*path)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
 	// Execute the download process with a progress callback.
-	err = provider.Download(*gameVersion, *serverVersion, *path, func(current, total int64) {
-		if total > 0 {
-			// If total size is known, display percentage progress.
-			fmt.Printf("\rDownloading... %.2f%%", float64(current)/float64(total)*100)
-		} else {
-			// Fallback for when total size is unknown.
-			fmt.Print("\rDownloading...")
-		}
+	err = provider.Download(*gameVersion, *serverVersion, *path, printProgress)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
 
-		// Clear the progress line once the download completes.
-		if total == current {
-			fmt.Print("\r\033[K")
+	// Make the run script written by the installer (e.g., Forge's run.sh) use the provisioned runtime,
+	// before the install is locked so that the lock records the script as it is left.
+	javaCommand := ""
+	if *withJava {
+		javaCommand, err = useRuntimeInRunScript(*path, *javaPath, logger)
+		if err != nil {
+			logger.Fatalf("Error: %v", err)
 		}
+	}
+
+	// Record the install in the lock file, after checking it against the lock file it was installed from.
+	lock, err := lockInstall(*path, before, lockfile.Lock{
+		ToolVersion:   version,
+		Provider:      providerInfo.Name,
+		GameVersion:   *gameVersion,
+		ServerVersion: *serverVersion,
+		Options: lockfile.Options{
+			Variant:          *variant,
+			InstallerVersion: *fabricInstaller,
+			ExtractBundler:   *extractBundler,
+			PatchPaperclip:   *patchPaperclip,
+			NativeInstall:    *nativeInstall,
+			RunInstaller:     *runInstaller && !*nativeInstall,
+			JavaSource:       runtimeSource,
+			JavaVersion:      runtimeVersion,
+		},
+		URL: downloadURL,
 	})
 	if err != nil {
 		logger.Fatalf("Error: %v", err)
 	}
+	if installLock != nil {
+		if err := checkLock(installLock, lock); err != nil {
+			logger.Fatalf("Error: the install differs from %s: %v", *lockPath, err)
+		}
+		logger.Printf("Verified %d files against %s", len(lock.Artifacts), *lockPath)
+	}
+	if err := lock.Write(filepath.Join(*path, lockfile.FileName)); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	logger.Printf("Wrote %s to %s", lockfile.FileName, *path)
+
+	// Accept the EULA, so that the first start doesn't stop to ask for it.
+	if *acceptEULA {
+		if err := serverconfig.AcceptEULA(*path, time.Now()); err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		logger.Printf("Accepted the Minecraft EULA (%s)", serverconfig.EULAURL)
+	}
+
+	// Seed server.properties from the template and the property flags.
+	if writeProperties {
+		if err := serverconfig.WriteServerProperties(*path, *propertiesTemplate, settings); err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		logger.Printf("Wrote server.properties to %s", *path)
+	}
+
+	// Update the player lists, resolving names the way the server will.
+	if !players.empty() {
+		if err := updatePlayerLists(context.Background(), *path, players, playerResolver(*path, *profileURL), logger); err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+	}
+
+	// Generate the start scripts, using the provisioned runtime in the script for this platform.
+	if *startScript {
+		if runtime.GOOS == "windows" {
+			scriptOptions.JavaWindows = javaCommand
+		} else {
+			scriptOptions.Java = javaCommand
+		}
+
+		err := startscript.Write(*path, scriptOptions)
+		if errors.Is(err, startscript.ErrInstallerNotRun) {
+			logger.Fatalf("Error: %v, use -install or -native-install to generate start scripts", err)
+		}
+		if err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		logger.Printf("Wrote start.sh and start.bat to %s", *path)
+	}
+}
+
+// printProgress displays the progress of a download.
+func printProgress(current, total int64) {
+	if total > 0 {
+		// If total size is known, display percentage progress.
+		fmt.Printf("\rDownloading... %.2f%%", float64(current)/float64(total)*100)
+	} else {
+		// Fallback for when total size is unknown.
+		fmt.Print("\rDownloading...")
+	}
+
+	// Clear the progress line once the download completes.
+	if total == current {
+		fmt.Print("\r\033[K")
+	}
+}
+
+// checkJavaVersion compares the Java major version required by the server with the one of the Java executable
+// (javaPath, or discovered from JAVA_HOME or the PATH) and returns an error if it is too old or missing.
+// A requirement that cannot be determined is only logged, since it says nothing about the installed Java.
+func checkJavaVersion(requirer mcprovider.JavaVersionRequirer, gameVersion, serverVersion, javaPath string, logger *log.Logger) error {
+	required, err := requirer.RequiredJavaVersion(gameVersion, serverVersion)
+	if err != nil {
+		logger.Printf("Warning: failed to determine the required Java version: %v", err)
+		return nil
+	}
+
+	java, err := internal.FindJava(javaPath)
+	if err != nil {
+		return fmt.Errorf("the server requires Java %d or newer, but %v", required, err)
+	}
+	installed, err := internal.JavaMajorVersion(context.Background(), java)
+	if err != nil {
+		return fmt.Errorf("the server requires Java %d or newer, but the installed version could not be detected: %v", required, err)
+	}
+	if installed < required {
+		return fmt.Errorf("the server requires Java %d or newer, but %s is Java %d", required, java, installed)
+	}
+
+	logger.Printf("Java %d satisfies the required Java %d", installed, required)
+	return nil
+}
+
+// provisionJava installs a Java runtime into the runtime directory of installDir and returns the absolute path
+// of its Java executable. Unless options pins a major version, it installs the one the server requires,
+// and records it in options.
+func provisionJava(requirer mcprovider.JavaVersionRequirer, gameVersion, serverVersion, installDir string, options *javaruntime.Options) (string, error) {
+	if options.MajorVersion == 0 {
+		required, err := requirer.RequiredJavaVersion(gameVersion, serverVersion)
+		if err != nil {
+			return "", fmt.Errorf("failed to determine the required Java version: %w", err)
+		}
+		options.MajorVersion = required
+	}
+
+	java, err := javaruntime.Install(context.Background(), filepath.Join(installDir, lockfile.RuntimeDir), *options)
+	if err != nil {
+		return "", fmt.Errorf("failed to install a Java %d runtime: %w", options.MajorVersion, err)
+	}
+
+	// Installer steps run in the install directory, so the path must not be relative
+	return filepath.Abs(java)
+}
+
+// useRuntimeInRunScript makes the run script an installer wrote into installDir (e.g., Forge's run.sh) for this
+// platform start the server with javaPath. It returns the command running javaPath from a script in installDir.
+func useRuntimeInRunScript(installDir, javaPath string, logger *log.Logger) (string, error) {
+	command, err := scriptJavaCommand(installDir, javaPath)
+	if err != nil {
+		return "", err
+	}
+
+	script := "run.sh"
+	if runtime.GOOS == "windows" {
+		script = "run.bat"
+	}
+	if _, err := javaruntime.UseInScript(filepath.Join(installDir, script), command); err != nil {
+		logger.Printf("Warning: failed to update %s: %v", script, err)
+	}
+	return command, nil
+}
+
+// scriptJavaCommand returns the command that runs javaPath from a start script in installDir.
+func scriptJavaCommand(installDir, javaPath string) (string, error) {
+	absDir, err := filepath.Abs(installDir)
+	if err != nil {
+		return "", err
+	}
+	return javaruntime.ScriptCommand(absDir, javaPath, runtime.GOOS)
+}
+
+// propertyFlags collects repeated -property key=value flags.
+type propertyFlags map[string]string
+
+func (p *propertyFlags) String() string {
+	pairs := make([]string, 0, len(*p))
+	for key, value := range *p {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p *propertyFlags) Set(pair string) error {
+	key, value, ok := strings.Cut(pair, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", pair)
+	}
+	if *p == nil {
+		*p = propertyFlags{}
+	}
+	(*p)[key] = value
+	return nil
+}
+
+// lockInstall completes the lock of an install with the files it wrote since the snapshot taken before it,
+// which include the intact files it kept. The provisioned Java runtime is recorded by the options instead.
+func lockInstall(dir string, before lockfile.Snapshot, lock lockfile.Lock) (*lockfile.Lock, error) {
+	after, err := lockfile.TakeSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, path := range before.Changed(after) {
+		if !strings.HasPrefix(path, lockfile.RuntimeDir+"/") {
+			paths = append(paths, path)
+		}
+	}
+	artifacts, err := lockfile.HashArtifacts(dir, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	lock.SchemaVersion = lockfile.SchemaVersion
+	lock.InstalledAt = time.Now().UTC().Truncate(time.Second)
+	lock.Artifacts = artifacts
+	return &lock, nil
+}
+
+// checkLock compares a new install with the lock it was installed from:
+// the download URL must be the same, and every locked file must have its locked digest.
+func checkLock(expected, actual *lockfile.Lock) error {
+	if expected.URL != "" && actual.URL != expected.URL {
+		return fmt.Errorf("the download URL changed from %s to %s", expected.URL, actual.URL)
+	}
+
+	digests := make(map[string]string, len(actual.Artifacts))
+	for _, artifact := range actual.Artifacts {
+		digests[artifact.Path] = artifact.SHA256
+	}
+	var mismatches []string
+	for _, artifact := range expected.Artifacts {
+		digest, ok := digests[artifact.Path]
+		if !ok {
+			mismatches = append(mismatches, artifact.Path+": not installed")
+		} else if digest != artifact.SHA256 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected sha256 %s, got %s", artifact.Path, artifact.SHA256, digest))
+		}
+	}
+	if len(mismatches) > 0 {
+		return errors.New(strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// listFlags collects the values of a repeated flag.
+type listFlags []string
+
+func (l *listFlags) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlags) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// playerListFlags holds the flags editing ops.json, whitelist.json and the ban lists.
+type playerListFlags struct {
+	op, deop, whitelist, unwhitelist, ban, pardon, banIP, pardonIP listFlags
+	opLevel                                                        int
+	banReason                                                      string
+}
+
+// register defines the player list flags on flags.
+func (f *playerListFlags) register(flags *flag.FlagSet) {
+	flags.Var(&f.op, "op", "Add a player to ops.json (repeatable)")
+	flags.IntVar(&f.opLevel, "op-level", 4, "Permission level of the players added with -op (1-4)")
+	flags.Var(&f.deop, "deop", "Remove a player from ops.json (repeatable)")
+	flags.Var(&f.whitelist, "whitelist", "Add a player to whitelist.json (repeatable)")
+	flags.Var(&f.unwhitelist, "unwhitelist", "Remove a player from whitelist.json (repeatable)")
+	flags.Var(&f.ban, "ban", "Add a player to banned-players.json (repeatable)")
+	flags.Var(&f.pardon, "pardon", "Remove a player from banned-players.json (repeatable)")
+	flags.Var(&f.banIP, "ban-ip", "Add an IP address to banned-ips.json (repeatable)")
+	flags.Var(&f.pardonIP, "pardon-ip", "Remove an IP address from banned-ips.json (repeatable)")
+	flags.StringVar(&f.banReason, "ban-reason", serverconfig.DefaultBanReason, "Reason of the bans added with -ban and -ban-ip")
+}
+
+// empty reports whether no player list is edited.
+func (f playerListFlags) empty() bool {
+	return len(f.op)+len(f.deop)+len(f.whitelist)+len(f.unwhitelist)+len(f.ban)+len(f.pardon)+len(f.banIP)+len(f.pardonIP) == 0
+}
+
+// serverProperties returns the server.properties of dir, or an empty file if it cannot be read,
+// whose typed getters return the server's defaults.
+func serverProperties(dir string) *serverconfig.Properties {
+	properties, err := serverconfig.LoadProperties(filepath.Join(dir, "server.properties"))
+	if err != nil {
+		return serverconfig.NewProperties()
+	}
+	return properties
+}
+
+// playerResolver resolves player names the way the server in dir will: offline-mode UUIDs
+// if its server.properties disables online mode, the profile API at profileURL otherwise.
+func playerResolver(dir, profileURL string) serverconfig.PlayerResolver {
+	if online, err := serverProperties(dir).OnlineMode(); err == nil && !online {
+		return serverconfig.OfflineResolver{}
+	}
+	return serverconfig.MojangResolver{URL: profileURL}
+}
+
+// updatePlayerLists applies the player list flags to the server directory. Removals don't need the UUIDs,
+// so only the added players are resolved.
+func updatePlayerLists(ctx context.Context, dir string, flags playerListFlags, resolver serverconfig.PlayerResolver, logger *log.Logger) error {
+	resolve := func(names listFlags) ([]serverconfig.Player, error) {
+		resolved := make([]serverconfig.Player, 0, len(names))
+		for _, name := range names {
+			player, err := resolver.ResolvePlayer(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, player)
+		}
+		return resolved, nil
+	}
+	remove := func(names listFlags, file string, removeFunc func(dir, name string) (bool, error)) error {
+		for _, name := range names {
+			removed, err := removeFunc(dir, name)
+			if err != nil {
+				return err
+			}
+			if removed {
+				logger.Printf("Removed %s from %s", name, file)
+			} else {
+				logger.Printf("Warning: %s is not in %s", name, file)
+			}
+		}
+		return nil
+	}
+
+	ops, err := resolve(flags.op)
+	if err != nil {
+		return err
+	}
+	whitelisted, err := resolve(flags.whitelist)
+	if err != nil {
+		return err
+	}
+	banned, err := resolve(flags.ban)
+	if err != nil {
+		return err
+	}
+
+	for _, player := range ops {
+		if err := serverconfig.AddOp(dir, player, flags.opLevel, false); err != nil {
+			return err
+		}
+		logger.Printf("Added %s (%s) to %s with level %d", player.Name, player.UUID, serverconfig.OpsFile, flags.opLevel)
+	}
+	for _, player := range whitelisted {
+		if err := serverconfig.AddToWhitelist(dir, player); err != nil {
+			return err
+		}
+		logger.Printf("Added %s (%s) to %s", player.Name, player.UUID, serverconfig.WhitelistFile)
+	}
+
+	ban := serverconfig.Ban{Created: time.Now(), Reason: flags.banReason}
+	for _, player := range banned {
+		if err := serverconfig.BanPlayer(dir, player, ban); err != nil {
+			return err
+		}
+		logger.Printf("Added %s (%s) to %s", player.Name, player.UUID, serverconfig.BannedPlayersFile)
+	}
+	for _, ip := range flags.banIP {
+		if err := serverconfig.BanIP(dir, ip, ban); err != nil {
+			return err
+		}
+		logger.Printf("Added %s to %s", ip, serverconfig.BannedIPsFile)
+	}
+
+	if err := remove(flags.deop, serverconfig.OpsFile, serverconfig.RemoveOp); err != nil {
+		return err
+	}
+	if err := remove(flags.unwhitelist, serverconfig.WhitelistFile, serverconfig.RemoveFromWhitelist); err != nil {
+		return err
+	}
+	if err := remove(flags.pardon, serverconfig.BannedPlayersFile, serverconfig.PardonPlayer); err != nil {
+		return err
+	}
+	return remove(flags.pardonIP, serverconfig.BannedIPsFile, serverconfig.PardonIP)
 }
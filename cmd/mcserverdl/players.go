@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/serverconfig"
+)
+
+// runPlayers implements "mcserverdl players": it edits the ops, whitelist and ban lists of an existing
+// server directory without installing anything.
+func runPlayers(args []string, logger *log.Logger) {
+	flags := flag.NewFlagSet("players", flag.ExitOnError)
+	path := flags.String("path", "./", "The server directory whose player lists are edited")
+	profileURL := flags.String("profile-url", serverconfig.MojangProfileURL, "Endpoint resolving player names to UUIDs in online mode (e.g., a local stand-in)")
+	var players playerListFlags
+	players.register(flags)
+	flags.Parse(args)
+
+	if players.empty() {
+		logger.Fatal("Error: no player list flag was given (e.g., -op, -whitelist, -ban)")
+	}
+	if info, err := os.Stat(*path); err != nil {
+		logger.Fatalf("Error: %v", err)
+	} else if !info.IsDir() {
+		logger.Fatalf("Error: %s is not a directory", *path)
+	}
+
+	if err := updatePlayerLists(context.Background(), *path, players, playerResolver(*path, *profileURL), logger); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/javaruntime"
+	"github.com/abulleDev/mcserverdl/v2/pkg/detect"
+	"github.com/abulleDev/mcserverdl/v2/pkg/factory"
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+	mcprovider "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/update"
+)
+
+// releaseVersion matches the game versions of releases (e.g., "1.21.4"), as opposed to snapshots and pre-releases.
+var releaseVersion = regexp.MustCompile(`^\d+(\.\d+)+$`)
+
+// runUpdate implements "mcserverdl update": it updates the server of an existing directory to the latest build
+// of its game version, or to the latest release if game updates are allowed.
+func runUpdate(args []string, logger *log.Logger) {
+	flags := flag.NewFlagSet("update", flag.ExitOnError)
+	path := flags.String("path", "./", "The server directory to update")
+	allowGameUpdate := flags.Bool("allow-game-update", false, "Update to the latest game release, not only to newer builds of the installed game version")
+	dryRun := flags.Bool("dry-run", false, "Print the update without installing it")
+	javaPath := flags.String("java", "", "Java executable used for installer steps and the Java version check (default from JAVA_HOME or PATH)")
+	flags.Parse(args)
+
+	// Find out what is installed, from the lock file or else from the server jar.
+	// Without a lock, only the detected jar is known to belong to the install, so it is the only file replaced
+	// besides the run scripts of servers that come with an installer.
+	previous, err := lockfile.Read(filepath.Join(*path, lockfile.FileName))
+	current := previous
+	var replace []string
+	detectedJar := ""
+	if os.IsNotExist(err) {
+		detected, err := detect.Detect(*path)
+		if err != nil {
+			logger.Fatalf("Error: cannot tell which server is installed without %s: %v", lockfile.FileName, err)
+		}
+		logger.Printf("Detected %s in %s", describeServer(detected.Provider, detected.GameVersion, detected.ServerVersion), *path)
+		current = &lockfile.Lock{Provider: detected.Provider, GameVersion: detected.GameVersion, ServerVersion: detected.ServerVersion}
+		if detected.Jar != "" {
+			detectedJar = detected.Jar
+			replace = []string{detected.Jar}
+		}
+	} else if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
+	provider, err := factory.New(current.Provider)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	providerInfo, _ := factory.Lookup(current.Provider)
+	provider.SetLogger(logger)
+
+	// Without a lock, the options of an install are unknown. Servers that come with an installer are updated by
+	// running it, since the installer alone would leave the installed libraries and run scripts starting the old version.
+	if _, ok := provider.(mcprovider.InstallerRunner); ok && previous == nil {
+		logger.Printf("Running the %s installer, as %s doesn't record how the server was installed", providerInfo.DisplayName, lockfile.FileName)
+		current.Options.RunInstaller = true
+		replace = append(replace, update.InstallerScripts...)
+	}
+
+	// Choose the game version: the installed one, or the latest release if it is newer and allowed.
+	gameVersion := current.GameVersion
+	if *allowGameUpdate {
+		latest, err := latestRelease(provider)
+		if err != nil {
+			logger.Fatalf("Error fetching the %s game versions: %v", providerInfo.DisplayName, err)
+		}
+		if latest != "" && internal.CompareVersions(latest, gameVersion) > 0 {
+			gameVersion = latest
+		}
+	}
+
+	// Choose the latest build of that game version.
+	serverVersion := ""
+	if providerInfo.HasServerVersions {
+		serverVersions, err := provider.ServerVersions(gameVersion)
+		if err != nil {
+			logger.Fatalf("Error fetching the %s server versions: %v", providerInfo.DisplayName, err)
+		}
+		if len(serverVersions) == 0 {
+			logger.Fatalf("Error: No server versions found for game version %s", gameVersion)
+		}
+		serverVersion = serverVersions[0]
+	}
+
+	from := describeServer(providerInfo.Name, current.GameVersion, current.ServerVersion)
+	to := describeServer(providerInfo.Name, gameVersion, serverVersion)
+	if gameVersion == current.GameVersion && serverVersion == current.ServerVersion {
+		logger.Printf("%s is up to date", from)
+		return
+	}
+	logger.Printf("Updating %s to %s", from, to)
+	if *dryRun {
+		return
+	}
+
+	// Provision the Java runtime the install recorded for the new version, which may require a newer one,
+	// and run the installer steps with it. Otherwise warn about a Java that is too old for the new version.
+	if current.Options.JavaSource != "" {
+		requirer, ok := provider.(mcprovider.JavaVersionRequirer)
+		if !ok {
+			logger.Fatalf("Error: %s does not run on Java", providerInfo.DisplayName)
+		}
+		options := javaruntime.Options{Source: current.Options.JavaSource, AdoptiumURL: javaruntime.AdoptiumURL, Log: logger.Printf}
+		java, err := provisionJava(requirer, gameVersion, serverVersion, *path, &options)
+		if err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		*javaPath = java
+		current.Options.JavaVersion = options.MajorVersion
+	} else if requirer, ok := provider.(mcprovider.JavaVersionRequirer); ok {
+		if err := checkJavaVersion(requirer, gameVersion, serverVersion, *javaPath, logger); err != nil {
+			logger.Printf("Warning: %v", err)
+		}
+	}
+	if err := configureProvider(provider, current.Options, *javaPath); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Download the new version next to the server, so that its files can be renamed into place.
+	stagingDir, err := os.MkdirTemp(*path, ".mcserverdl-update-")
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	downloadURL, err := provider.DownloadURL(gameVersion, serverVersion)
+	if err != nil {
+		logger.Printf("Warning: failed to determine the download URL: %v", err)
+	}
+	if err := provider.Download(gameVersion, serverVersion, stagingDir, printProgress); err != nil {
+		os.RemoveAll(stagingDir)
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Without a lock, the server is started with the detected jar, whatever the new server jar is called.
+	if detectedJar != "" {
+		if err := renameStagedJar(stagingDir, detectedJar, logger); err != nil {
+			os.RemoveAll(stagingDir)
+			logger.Fatalf("Error: %v", err)
+		}
+	}
+
+	backupDir := filepath.Join(*path, ".mcserverdl-backup", time.Now().Format("20060102-150405"))
+	result, err := update.Apply(*path, stagingDir, update.Options{Previous: previous, Replace: replace, BackupDir: backupDir})
+	if err != nil {
+		os.RemoveAll(stagingDir)
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Point the new run script of the installer at the provisioned runtime again, before it is locked.
+	if current.Options.JavaSource != "" {
+		if _, err := useRuntimeInRunScript(*path, *javaPath, logger); err != nil {
+			logger.Printf("Warning: %v", err)
+		}
+	}
+
+	// Record the new install, keeping the previous lock with the backup.
+	if previous != nil {
+		if err := os.MkdirAll(backupDir, 0755); err == nil {
+			err = previous.Write(filepath.Join(backupDir, lockfile.FileName))
+		}
+		if err != nil {
+			logger.Printf("Warning: failed to back up %s: %v", lockfile.FileName, err)
+		}
+	}
+	artifacts, err := lockfile.HashArtifacts(*path, result.Installed())
+	if err != nil {
+		os.RemoveAll(stagingDir)
+		logger.Fatalf("Error: %v", err)
+	}
+	lock := lockfile.Lock{
+		SchemaVersion: lockfile.SchemaVersion,
+		ToolVersion:   version,
+		Provider:      providerInfo.Name,
+		GameVersion:   gameVersion,
+		ServerVersion: serverVersion,
+		Options:       current.Options,
+		URL:           downloadURL,
+		InstalledAt:   time.Now().UTC().Truncate(time.Second),
+		Artifacts:     artifacts,
+	}
+	if err := lock.Write(filepath.Join(*path, lockfile.FileName)); err != nil {
+		os.RemoveAll(stagingDir)
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Print what changed.
+	logger.Printf("Updated %s to %s", from, to)
+	for _, change := range []struct {
+		label string
+		paths []string
+	}{
+		{"Added", result.Added},
+		{"Replaced", result.Replaced},
+		{"Removed", result.Removed},
+		{"Kept (changed locally)", result.Kept},
+	} {
+		for _, path := range change.paths {
+			logger.Printf("  %s: %s", change.label, path)
+		}
+	}
+	if len(result.Replaced) > 0 || len(result.Removed) > 0 {
+		logger.Printf("Backed up the old files to %s", backupDir)
+	}
+}
+
+// renameStagedJar gives the server jar of a staged download the name of the installed jar the server is started
+// with (e.g., "paper-1.21.4-100.jar" for a new "server.jar"), so that the update replaces it. If the new version
+// is not started with a server jar, it warns that the command starting the server must change.
+func renameStagedJar(stagingDir, jar string, logger *log.Logger) error {
+	staged, err := detect.Detect(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to find the server jar of the new version: %w", err)
+	}
+	switch staged.Jar {
+	case jar:
+		return nil
+	case "":
+		logger.Printf("Warning: the new version is not started with %s anymore; start it with its run script instead", jar)
+		return nil
+	}
+
+	logger.Printf("Installing %s as %s, the jar the server is started with", staged.Jar, jar)
+	return os.Rename(filepath.Join(stagingDir, filepath.FromSlash(staged.Jar)), filepath.Join(stagingDir, filepath.FromSlash(jar)))
+}
+
+// configureProvider applies the recorded install options to a provider.
+func configureProvider(provider mcprovider.Provider, options lockfile.Options, javaPath string) error {
+	if options.ExtractBundler {
+		extractor, ok := provider.(mcprovider.BundlerExtractor)
+		if !ok {
+			return fmt.Errorf("the server type does not support bundler extraction")
+		}
+		extractor.SetExtractBundler(true)
+	}
+	if options.InstallerVersion != "" {
+		selector, ok := provider.(mcprovider.InstallerVersionSelector)
+		if !ok {
+			return fmt.Errorf("the server type does not use a Fabric installer")
+		}
+		selector.SetInstallerVersion(options.InstallerVersion)
+	}
+	if options.Variant != "" {
+		selector, ok := provider.(mcprovider.VariantSelector)
+		if !ok {
+			return fmt.Errorf("the server type does not have download variants")
+		}
+		selector.SetVariant(options.Variant)
+	}
+	if options.PatchPaperclip {
+		patcher, ok := provider.(mcprovider.PaperclipPatcher)
+		if !ok {
+			return fmt.Errorf("the server type does not support Paperclip patching")
+		}
+		patcher.SetPatchPaperclip(true)
+	}
+	if options.NativeInstall {
+		installer, ok := provider.(mcprovider.NativeInstaller)
+		if !ok {
+			return fmt.Errorf("the server type does not support native installation")
+		}
+		installer.SetNativeInstall(true, javaPath)
+	} else if options.RunInstaller {
+		runner, ok := provider.(mcprovider.InstallerRunner)
+		if !ok {
+			return fmt.Errorf("the server type does not have an installer to run")
+		}
+		runner.SetRunInstaller(true, javaPath)
+	}
+	return nil
+}
+
+// latestRelease returns the newest release among the game versions of a provider, or an empty string if there is none.
+func latestRelease(provider mcprovider.Provider) (string, error) {
+	gameVersions, err := provider.GameVersions()
+	if err != nil {
+		return "", err
+	}
+
+	latest := ""
+	for _, gameVersion := range gameVersions {
+		if releaseVersion.MatchString(gameVersion) && (latest == "" || internal.CompareVersions(gameVersion, latest) > 0) {
+			latest = gameVersion
+		}
+	}
+	return latest, nil
+}
+
+// describeServer formats a server type and its versions for messages (e.g., "paper 1.21.4 (232)").
+func describeServer(provider, gameVersion, serverVersion string) string {
+	if serverVersion == "" {
+		return provider + " " + gameVersion
+	}
+	return provider + " " + gameVersion + " (" + serverVersion + ")"
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/detect"
+	"github.com/abulleDev/mcserverdl/v2/pkg/factory"
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+	mcprovider "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/verify"
+)
+
+// errUnknownServerVersion is returned by upstreamChecksum for detected servers whose build is not recorded in the jar.
+var errUnknownServerVersion = errors.New("the server version is unknown")
+
+// runVerify implements "mcserverdl verify": it recomputes the digests of the server files in a directory
+// and exits with status 1 if a file is missing or modified.
+func runVerify(args []string, logger *log.Logger) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	path := flags.String("path", "./", "The server directory to verify")
+	upstream := flags.Bool("upstream", false, "Also compare the server download with the checksum published upstream (always done without a lock file)")
+	strict := flags.Bool("strict", false, "Also fail on extra files in libraries/, versions/ and top-level jars that the lock file doesn't record (extra files are only found with a lock file)")
+	flags.Parse(args)
+
+	// Find out what is installed, from the lock file or else from the server jar.
+	lock, err := lockfile.Read(filepath.Join(*path, lockfile.FileName))
+	var current *lockfile.Lock
+	var jars, installed []string
+	var expected []verify.Expected
+	if os.IsNotExist(err) {
+		detected, err := detect.Detect(*path)
+		if err != nil {
+			logger.Fatalf("Error: cannot tell which server is installed without %s: %v", lockfile.FileName, err)
+		}
+		logger.Printf("Detected %s in %s", describeServer(detected.Provider, detected.GameVersion, detected.ServerVersion), *path)
+		current = &lockfile.Lock{Provider: detected.Provider, GameVersion: detected.GameVersion, ServerVersion: detected.ServerVersion}
+		if detected.Jar != "" {
+			jars = []string{detected.Jar}
+			installed = jars
+		}
+	} else if err != nil {
+		logger.Fatalf("Error: %v", err)
+	} else {
+		current = lock
+		expected = verify.FromLock(lock)
+		for _, artifact := range lock.Artifacts {
+			installed = append(installed, artifact.Path)
+			if !strings.Contains(artifact.Path, "/") && strings.HasSuffix(strings.ToLower(artifact.Path), ".jar") {
+				jars = append(jars, artifact.Path)
+			}
+		}
+	}
+	server := describeServer(current.Provider, current.GameVersion, current.ServerVersion)
+
+	// Bundler and Paperclip jars list the files they extract into versions/ and libraries/.
+	for _, jar := range jars {
+		listed, err := verify.FromBundler(*path, jar)
+		if errors.Is(err, zip.ErrFormat) || os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			logger.Fatalf("Error: %s: %v", jar, err)
+		}
+		expected = append(expected, listed...)
+	}
+
+	if lock == nil || *upstream {
+		checksum, err := upstreamChecksum(current)
+		switch {
+		case errors.Is(err, mcprovider.ErrNoChecksum), errors.Is(err, errUnknownServerVersion):
+			logger.Printf("Skipping the upstream checksum of %s: %v", server, err)
+		case err != nil:
+			logger.Fatalf("Error fetching the upstream checksum of %s: %v", server, err)
+		case !slices.Contains(installed, checksum.Path):
+			logger.Printf("Skipping the upstream checksum of %s: %s is not installed", server, checksum.Path)
+		default:
+			expected = append(expected, verify.Expected{Path: checksum.Path, Algorithm: checksum.Algorithm, Digest: checksum.Digest, Source: "upstream " + checksum.Algorithm})
+		}
+
+		// Installers delete themselves, so the libraries they installed are checked against their Maven repositories.
+		libraries, err := mavenLibraries(current, *path)
+		if err != nil {
+			logger.Fatalf("Error fetching the Maven checksums of the %s libraries: %v", server, err)
+		}
+		if len(libraries) > 0 {
+			logger.Printf("Checking %d libraries against the checksums of their Maven repositories", len(libraries))
+		}
+		expected = append(expected, libraries...)
+	}
+	if len(expected) == 0 {
+		logger.Fatalf("Error: nothing to verify %s against; install it with mcserverdl to record a %s", server, lockfile.FileName)
+	}
+
+	// Extra files can only be told apart from the install with a lock file.
+	report, err := verify.Check(*path, expected, lock != nil)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
+	counts := map[verify.Kind]int{}
+	for _, issue := range report.Issues {
+		counts[issue.Kind]++
+		if issue.Kind == verify.Extra && !*strict {
+			logger.Printf("Warning: %s", issue)
+		} else {
+			logger.Printf("  %s", issue)
+		}
+	}
+	if report.Failed(*strict) {
+		logger.Printf("Verification of %s failed: %d missing, %d modified, %d extra", server, counts[verify.Missing], counts[verify.Modified], counts[verify.Extra])
+		os.Exit(1)
+	}
+	logger.Printf("Verified %s: no missing or modified files (%d checked)", server, len(report.Verified))
+}
+
+// mavenLibraries returns the libraries of an install in dir with the checksums the Maven repositories of its provider
+// publish, or nothing if the provider doesn't install libraries from Maven repositories.
+func mavenLibraries(install *lockfile.Lock, dir string) ([]verify.Expected, error) {
+	provider, err := factory.New(install.Provider)
+	if err != nil {
+		return nil, err
+	}
+	repositories, ok := provider.(mcprovider.LibraryRepositoryProvider)
+	if !ok {
+		return nil, nil
+	}
+	return verify.FromMaven(context.Background(), dir, repositories.LibraryRepositories())
+}
+
+// upstreamChecksum fetches the checksum upstream publishes for the download of an install.
+func upstreamChecksum(install *lockfile.Lock) (mcprovider.Checksum, error) {
+	provider, err := factory.New(install.Provider)
+	if err != nil {
+		return mcprovider.Checksum{}, err
+	}
+	checksummer, ok := provider.(mcprovider.ChecksumProvider)
+	if !ok {
+		return mcprovider.Checksum{}, fmt.Errorf("%s: %w", install.Provider, mcprovider.ErrNoChecksum)
+	}
+	if providerInfo, _ := factory.Lookup(install.Provider); providerInfo.HasServerVersions && install.ServerVersion == "" {
+		return mcprovider.Checksum{}, errUnknownServerVersion
+	}
+	if err := configureProvider(provider, install.Options, ""); err != nil {
+		return mcprovider.Checksum{}, err
+	}
+	return checksummer.Checksum(install.GameVersion, install.ServerVersion)
+}
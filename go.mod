@@ -1,3 +1,5 @@
 module github.com/abulleDev/mcserverdl/v2
 
 go 1.23.5
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// bsdiffMagic is the header of a BSDIFF40 patch.
+const bsdiffMagic = "BSDIFF40"
+
+// ApplyBsdiff applies a BSDIFF40 patch (as produced by bsdiff and jbsdiff) to old and returns the new content.
+//
+// A BSDIFF40 patch consists of a 32-byte header followed by three bzip2-compressed blocks:
+// the control block, the diff block and the extra block.
+//
+// Parameters:
+//   - old: the original content.
+//   - patch: the patch content.
+//
+// Returns:
+//   - []byte: the patched content.
+//   - error: an error if the patch is corrupt or does not fit the original content.
+func ApplyBsdiff(old, patch []byte) ([]byte, error) {
+	if len(patch) < 32 || string(patch[:8]) != bsdiffMagic {
+		return nil, errors.New("invalid bsdiff patch header")
+	}
+
+	controlLength := bsdiffInt(patch[8:16])
+	diffLength := bsdiffInt(patch[16:24])
+	newSize := bsdiffInt(patch[24:32])
+	if controlLength < 0 || diffLength < 0 || newSize < 0 || 32+controlLength+diffLength > int64(len(patch)) {
+		return nil, errors.New("invalid bsdiff patch header")
+	}
+
+	controlStart := int64(32)
+	diffStart := controlStart + controlLength
+	extraStart := diffStart + diffLength
+
+	control := bzip2.NewReader(bytes.NewReader(patch[controlStart:diffStart]))
+	diff := bzip2.NewReader(bytes.NewReader(patch[diffStart:extraStart]))
+	extra := bzip2.NewReader(bytes.NewReader(patch[extraStart:]))
+
+	result := make([]byte, newSize)
+	var oldPos, newPos int64
+	var triple [24]byte
+
+	for newPos < newSize {
+		if _, err := io.ReadFull(control, triple[:]); err != nil {
+			return nil, fmt.Errorf("failed to read bsdiff control block: %w", err)
+		}
+		diffCount := bsdiffInt(triple[0:8])
+		extraCount := bsdiffInt(triple[8:16])
+		seek := bsdiffInt(triple[16:24])
+
+		if diffCount < 0 || extraCount < 0 || newPos+diffCount+extraCount > newSize {
+			return nil, errors.New("corrupt bsdiff patch: control data out of range")
+		}
+
+		// Add the diff bytes to the old content
+		if _, err := io.ReadFull(diff, result[newPos:newPos+diffCount]); err != nil {
+			return nil, fmt.Errorf("failed to read bsdiff diff block: %w", err)
+		}
+		for i := int64(0); i < diffCount; i++ {
+			if oldPos+i >= 0 && oldPos+i < int64(len(old)) {
+				result[newPos+i] += old[oldPos+i]
+			}
+		}
+		newPos += diffCount
+		oldPos += diffCount
+
+		// Copy the extra bytes as they are
+		if _, err := io.ReadFull(extra, result[newPos:newPos+extraCount]); err != nil {
+			return nil, fmt.Errorf("failed to read bsdiff extra block: %w", err)
+		}
+		newPos += extraCount
+		oldPos += seek
+	}
+
+	return result, nil
+}
+
+// bsdiffInt decodes a bsdiff integer: 8 bytes little-endian magnitude with the sign in the highest bit.
+func bsdiffInt(b []byte) int64 {
+	value := binary.LittleEndian.Uint64(b)
+	magnitude := int64(value &^ (1 << 63))
+	if value&(1<<63) != 0 {
+		return -magnitude
+	}
+	return magnitude
+}
@@ -0,0 +1,52 @@
+package internal_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// testBsdiffPatch turns "hello vanilla server" into "Hello paper server!!".
+// It was generated with bsdiff's format and uses two control entries, a diff byte that wraps around and a seek.
+const testBsdiffPatch = "QlNESUZGNDAyAAAAAAAAACkAAAAAAAAAFAAAAAAAAABCWmg5MUFZJlNZiyoDdQAAEMBAW4BAACAAMQwAlND1FuNqUDJKJ0O8XckU4UJCLKgN1EJaaDkxQVkmU1lbAuBOAAACwAFAQEAAIAAhJkGYkLi7kinChILYFwJwQlpoOTFBWSZTWUeILbgAAAIRgCAAIgBQACAAIYNBmgIKY4u5IpwoSCPEFtwA"
+
+const (
+	testBsdiffOld = "hello vanilla server"
+	testBsdiffNew = "Hello paper server!!"
+)
+
+func testBsdiffPatchBytes(t *testing.T) []byte {
+	t.Helper()
+	patch, err := base64.StdEncoding.DecodeString(testBsdiffPatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return patch
+}
+
+func TestApplyBsdiff(t *testing.T) {
+	patch := testBsdiffPatchBytes(t)
+
+	t.Run("success", func(t *testing.T) {
+		result, err := internal.ApplyBsdiff([]byte(testBsdiffOld), patch)
+		if err != nil {
+			t.Fatalf("ApplyBsdiff failed: %v", err)
+		}
+		if string(result) != testBsdiffNew {
+			t.Errorf("expected %q, got %q", testBsdiffNew, result)
+		}
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		if _, err := internal.ApplyBsdiff([]byte(testBsdiffOld), []byte("not a patch")); err == nil {
+			t.Fatal("expected error for invalid header, got nil")
+		}
+	})
+
+	t.Run("truncated patch", func(t *testing.T) {
+		if _, err := internal.ApplyBsdiff([]byte(testBsdiffOld), patch[:len(patch)-20]); err == nil {
+			t.Fatal("expected error for truncated patch, got nil")
+		}
+	})
+}
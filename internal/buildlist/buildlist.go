@@ -0,0 +1,196 @@
+// Package buildlist implements the provider methods shared by server types whose builds are listed by a CI server
+// or a release page (e.g., Jenkins jobs and GitHub releases), where every build carries a single server jar.
+package buildlist
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
+
+// Build is a build whose artifacts contain a server jar.
+type Build struct {
+	// GameVersion is the Minecraft version of the build (e.g., "1.21.4").
+	GameVersion string
+
+	// ServerVersion identifies the build (e.g., a Jenkins build number or a release tag).
+	ServerVersion string
+
+	// URL is the download URL of the server jar.
+	URL string
+}
+
+// Provider implements the Provider methods on top of a function listing the builds.
+// It is intended to be embedded in specific provider implementations.
+type Provider struct {
+	provider.BaseProvider
+
+	// The servers published this way are forks of the vanilla server and run on the same Java.
+	vanilla.JavaBaseline
+
+	// Name is the display name used in log messages (e.g., "Pufferfish").
+	Name string
+
+	// Noun names a server version in messages (e.g., "build" or "release").
+	Noun string
+
+	// Fetch fetches the builds, newest first. If gameVersion is not empty, only the builds of that
+	// game version are needed and the builds of other game versions may be left out.
+	Fetch func(ctx context.Context, gameVersion string) ([]Build, error)
+}
+
+// GameVersions fetches the list of all game versions that have a build.
+// It uses a default background context.
+func (p *Provider) GameVersions() ([]string, error) {
+	return p.GameVersionsContext(context.Background())
+}
+
+// GameVersionsContext fetches the list of all game versions that have a build with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//
+// Returns:
+//   - []string: a slice of Minecraft versions (e.g., "1.21.4", "1.20.4"), newest build first.
+//   - error: an error if any HTTP or JSON decoding issues occur.
+func (p *Provider) GameVersionsContext(ctx context.Context) ([]string, error) {
+	p.Log("Fetching supported %s game versions...", p.Name)
+
+	builds, err := p.Fetch(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Use a map to store unique game versions to avoid duplicates
+	versions := make([]string, 0)
+	versionSet := map[string]struct{}{}
+	for _, build := range builds {
+		if _, exist := versionSet[build.GameVersion]; !exist {
+			versionSet[build.GameVersion] = struct{}{}
+			versions = append(versions, build.GameVersion)
+		}
+	}
+
+	p.Log("Fetched %d %s game versions", len(versions), p.Name)
+	return versions, nil
+}
+
+// ServerVersions fetches the list of all builds for a given game version.
+// It uses a default background context.
+func (p *Provider) ServerVersions(gameVersion string) ([]string, error) {
+	return p.ServerVersionsContext(context.Background(), gameVersion)
+}
+
+// ServerVersionsContext fetches the list of all builds for a given game version with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.4", "1.20.4").
+//
+// Returns:
+//   - []string: a slice of build numbers or release tags for the specified game version, newest first.
+//   - error: an error if the game version is not supported or if any HTTP or JSON decoding issues occur.
+func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string) ([]string, error) {
+	p.Log("Fetching %s server versions (%ss) for %s...", p.Name, p.Noun, gameVersion)
+
+	builds, err := p.Fetch(ctx, gameVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0)
+	for _, build := range builds {
+		if build.GameVersion == gameVersion {
+			versions = append(versions, build.ServerVersion)
+		}
+	}
+
+	// If no matching builds were found, the game version is unsupported
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
+	}
+
+	p.Log("Fetched %d %s %ss for %s", len(versions), p.Name, p.Noun, gameVersion)
+	return versions, nil
+}
+
+// DownloadURL returns the download URL of the server jar for a given game version and build.
+// It uses a default background context.
+func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error) {
+	return p.DownloadURLContext(context.Background(), gameVersion, serverVersion)
+}
+
+// DownloadURLContext returns the download URL of the server jar for a given game version and build with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.4", "1.20.4").
+//   - serverVersion: the build number or release tag for the specified version.
+//
+// Returns:
+//   - string: the direct download URL for the server JAR if the build exists.
+//   - error: an error if the game version or build is not found, or if any HTTP or JSON decoding issues occur.
+func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
+	p.Log("Fetching download URL for %s %s %s %s...", p.Name, gameVersion, p.Noun, serverVersion)
+
+	builds, err := p.Fetch(ctx, gameVersion)
+	if err != nil {
+		return "", err
+	}
+
+	gameFound := false
+	for _, build := range builds {
+		if build.GameVersion != gameVersion {
+			continue
+		}
+		gameFound = true
+
+		if build.ServerVersion == serverVersion {
+			p.Log("Fetched %s download URL: %s", p.Name, build.URL)
+			return build.URL, nil
+		}
+	}
+
+	if !gameFound {
+		return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+	}
+	return "", fmt.Errorf("%s %s not found for version %s", p.Noun, serverVersion, gameVersion)
+}
+
+// Download downloads the server jar to the specified installation directory.
+// It uses a default background context.
+func (p *Provider) Download(gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
+	return p.DownloadContext(context.Background(), gameVersion, serverVersion, installDir, onProgress)
+}
+
+// DownloadContext downloads the server jar to the specified installation directory with context support.
+//
+// Parameters:
+//   - ctx: the context to control the download cancellation.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.4", "1.20.4").
+//   - serverVersion: the build number or release tag.
+//   - installDir: the directory where the server JAR will be saved.
+//   - onProgress: a callback function to report download progress.
+//
+// Returns:
+//   - error: an error if the download fails.
+func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
+	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return err
+	}
+
+	p.Log("Downloading server...")
+
+	serverJarPath := filepath.Join(installDir, "server.jar")
+	if err := internal.Download(ctx, url, serverJarPath, onProgress); err != nil {
+		return err
+	}
+
+	p.Log("Successfully downloaded server to %s", installDir)
+	return nil
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotBundler is returned by ExtractBundler for server jars that are not Mojang bundlers (Minecraft 1.17 and older).
+var ErrNotBundler = errors.New("not a bundler jar")
+
+// BundlerLayout describes the runnable layout produced by ExtractBundler.
+type BundlerLayout struct {
+	// MainClass is the server main class (e.g., "net.minecraft.server.Main").
+	MainClass string
+
+	// Classpath lists the extracted jars relative to the destination directory, using forward slashes.
+	// The server jars come first, followed by the libraries.
+	Classpath []string
+}
+
+// BundlerEntry is a single line of "META-INF/versions.list" or "META-INF/libraries.list".
+type BundlerEntry struct {
+	// SHA256 is the hex-encoded SHA-256 hash of the file.
+	SHA256 string
+
+	// ID identifies the file, e.g. the game version of a server jar or the Maven coordinate of a library.
+	ID string
+
+	// Path is the path of the file below "META-INF/versions/" or "META-INF/libraries/".
+	Path string
+}
+
+// ExtractBundler unpacks a Mojang bundler server jar (Minecraft 1.18 and newer) into destDir.
+// The server jars listed in "META-INF/versions.list" are written to "versions/" and the libraries
+// listed in "META-INF/libraries.list" to "libraries/", each verified against the SHA-256 hash of its list.
+// Files that already exist with the expected hash are not rewritten.
+//
+// Parameters:
+//   - ctx: the context to control cancellation.
+//   - jarPath: the path of the bundler jar.
+//   - destDir: the directory to extract into.
+//
+// Returns:
+//   - *BundlerLayout: the main class and classpath of the extracted server.
+//   - error: ErrNotBundler if the jar is not a bundler, or an error if an entry is missing, invalid or fails verification.
+func ExtractBundler(ctx context.Context, jarPath, destDir string) (*BundlerLayout, error) {
+	jar, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer jar.Close()
+
+	files := ZipEntries(&jar.Reader)
+	if _, ok := files["META-INF/versions.list"]; !ok {
+		return nil, ErrNotBundler
+	}
+
+	return extractBundled(ctx, files, destDir, files)
+}
+
+// extractBundled extracts the files listed in the bundler lists of jar into destDir.
+// Each listed file is taken from the first of sources that contains it.
+func extractBundled(ctx context.Context, jar map[string]*zip.File, destDir string, sources ...map[string]*zip.File) (*BundlerLayout, error) {
+	mainClassData, err := ReadZipEntry(jar, "META-INF/main-class")
+	if err != nil {
+		return nil, err
+	}
+	layout := &BundlerLayout{MainClass: strings.TrimSpace(string(mainClassData))}
+
+	for _, kind := range []string{"versions", "libraries"} {
+		entries, err := ReadBundlerList(jar, "META-INF/"+kind+".list")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			cleanPath, err := cleanListPath(entry.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			relativePath := kind + "/" + cleanPath
+			targetPath := filepath.Join(destDir, filepath.FromSlash(relativePath))
+			layout.Classpath = append(layout.Classpath, relativePath)
+
+			if VerifyFile(targetPath, "sha256", entry.SHA256) == nil {
+				if err := Touch(targetPath); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			file := findBundled(sources, "META-INF/"+kind+"/"+entry.Path)
+			if file == nil {
+				return nil, fmt.Errorf("bundled file %s (%s) is missing", entry.Path, entry.ID)
+			}
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return nil, err
+			}
+			if err := extractZipFile(file, targetPath); err != nil {
+				return nil, fmt.Errorf("failed to extract '%s': %w", entry.Path, err)
+			}
+			if err := VerifyFile(targetPath, "sha256", entry.SHA256); err != nil {
+				os.Remove(targetPath)
+				return nil, fmt.Errorf("failed to verify %s: %w", entry.ID, err)
+			}
+		}
+	}
+
+	return layout, nil
+}
+
+// cleanListPath cleans a relative path read from a list file and rejects paths escaping the target directory.
+func cleanListPath(listPath string) (string, error) {
+	cleanPath := path.Clean(listPath)
+	if path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return "", fmt.Errorf("illegal path in list: %s", listPath)
+	}
+	return cleanPath, nil
+}
+
+// findBundled returns the entry with the given name from the first source that contains it, or nil.
+func findBundled(sources []map[string]*zip.File, name string) *zip.File {
+	for _, source := range sources {
+		if file, ok := source[name]; ok {
+			return file
+		}
+	}
+	return nil
+}
+
+// ZipEntries indexes the entries of a zip archive by name.
+func ZipEntries(archive *zip.Reader) map[string]*zip.File {
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+	return files
+}
+
+// ReadBundlerList parses a bundler list file, where each line holds a SHA-256 hash, an ID and a path separated by tabs.
+func ReadBundlerList(files map[string]*zip.File, name string) ([]BundlerEntry, error) {
+	data, err := ReadZipEntry(files, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []BundlerEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid line in %s: %q", name, line)
+		}
+		entries = append(entries, BundlerEntry{SHA256: fields[0], ID: fields[1], Path: fields[2]})
+	}
+
+	return entries, scanner.Err()
+}
+
+// ReadZipEntry reads the full content of a zip entry.
+func ReadZipEntry(files map[string]*zip.File, name string) ([]byte, error) {
+	file, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in jar", name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestExtractBundler(t *testing.T) {
+	tempDir := t.TempDir()
+
+	const serverContent = "server classes"
+	const libraryContent = "library classes"
+
+	bundlerPath := filepath.Join(tempDir, "server.jar")
+	createTestZip(t, bundlerPath, map[string]string{
+		"META-INF/main-class":    "net.minecraft.server.Main\n",
+		"META-INF/versions.list": fmt.Sprintf("%s\t1.21\t1.21/server-1.21.jar\n", sha256Hex(serverContent)),
+		"META-INF/libraries.list": fmt.Sprintf("%s\tcom.mojang:logging:1.2.7\tcom/mojang/logging/1.2.7/logging-1.2.7.jar\n",
+			sha256Hex(libraryContent)),
+		"META-INF/versions/1.21/server-1.21.jar":                        serverContent,
+		"META-INF/libraries/com/mojang/logging/1.2.7/logging-1.2.7.jar": libraryContent,
+	})
+
+	t.Run("success", func(t *testing.T) {
+		destDir := filepath.Join(tempDir, "out")
+		layout, err := internal.ExtractBundler(context.Background(), bundlerPath, destDir)
+		if err != nil {
+			t.Fatalf("ExtractBundler failed: %v", err)
+		}
+
+		if layout.MainClass != "net.minecraft.server.Main" {
+			t.Errorf("unexpected main class: %s", layout.MainClass)
+		}
+		expectedClasspath := []string{"versions/1.21/server-1.21.jar", "libraries/com/mojang/logging/1.2.7/logging-1.2.7.jar"}
+		if !slices.Equal(layout.Classpath, expectedClasspath) {
+			t.Errorf("unexpected classpath: %v", layout.Classpath)
+		}
+
+		expectedFiles := map[string]string{
+			"versions/1.21/server-1.21.jar":                        serverContent,
+			"libraries/com/mojang/logging/1.2.7/logging-1.2.7.jar": libraryContent,
+		}
+		for name, want := range expectedFiles {
+			content, err := os.ReadFile(filepath.Join(destDir, filepath.FromSlash(name)))
+			if err != nil || string(content) != want {
+				t.Errorf("unexpected content for %s: %q (%v)", name, content, err)
+			}
+		}
+	})
+
+	t.Run("intact files are kept and touched", func(t *testing.T) {
+		destDir := filepath.Join(tempDir, "kept")
+		if _, err := internal.ExtractBundler(context.Background(), bundlerPath, destDir); err != nil {
+			t.Fatalf("ExtractBundler failed: %v", err)
+		}
+		serverPath := filepath.Join(destDir, "versions/1.21/server-1.21.jar")
+		old := time.Now().Add(-time.Hour).Truncate(time.Second)
+		if err := os.Chtimes(serverPath, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := internal.ExtractBundler(context.Background(), bundlerPath, destDir); err != nil {
+			t.Fatalf("ExtractBundler failed: %v", err)
+		}
+		info, err := os.Stat(serverPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info.ModTime().After(old) {
+			t.Errorf("expected the kept file to be touched, modification time is still %v", info.ModTime())
+		}
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		badPath := filepath.Join(tempDir, "bad.jar")
+		createTestZip(t, badPath, map[string]string{
+			"META-INF/main-class":                    "net.minecraft.server.Main",
+			"META-INF/versions.list":                 fmt.Sprintf("%s\t1.21\t1.21/server-1.21.jar\n", sha256Hex("other")),
+			"META-INF/libraries.list":                "",
+			"META-INF/versions/1.21/server-1.21.jar": serverContent,
+		})
+
+		destDir := filepath.Join(tempDir, "bad")
+		if _, err := internal.ExtractBundler(context.Background(), badPath, destDir); err == nil {
+			t.Fatal("expected error for hash mismatch, got nil")
+		}
+		if _, err := os.Stat(filepath.Join(destDir, "versions/1.21/server-1.21.jar")); !os.IsNotExist(err) {
+			t.Error("file with mismatching hash should have been removed")
+		}
+	})
+
+	t.Run("not a bundler", func(t *testing.T) {
+		plainPath := filepath.Join(tempDir, "plain.jar")
+		createTestZip(t, plainPath, map[string]string{"META-INF/MANIFEST.MF": "Main-Class: net.minecraft.server.MinecraftServer\n"})
+
+		_, err := internal.ExtractBundler(context.Background(), plainPath, filepath.Join(tempDir, "plain"))
+		if !errors.Is(err, internal.ErrNotBundler) {
+			t.Fatalf("expected ErrNotBundler, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractZip extracts every entry of the zip archive at zipPath into destDir.
+// Entries for which skip returns true are left untouched on disk; skip may be nil.
+// Entries that would be written outside of destDir are rejected.
+func ExtractZip(ctx context.Context, zipPath, destDir string, skip func(name string) bool) error {
+	// Check if context is already cancelled
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	zipReader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if skip != nil && skip(file.Name) {
+			continue
+		}
+
+		// Reject entries escaping the destination directory (zip slip)
+		targetPath, err := SafeJoin(destDir, file.Name)
+		if err != nil {
+			return err
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return err
+		}
+
+		if err := extractZipFile(file, targetPath); err != nil {
+			return fmt.Errorf("failed to extract file '%s': %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SafeJoin joins a slash-separated path read from an archive or a manifest to dir,
+// rejecting paths that would escape dir (e.g., "../evil" or "/etc/passwd").
+func SafeJoin(dir, name string) (string, error) {
+	cleanDir := filepath.Clean(dir)
+	target := filepath.Join(cleanDir, filepath.FromSlash(name))
+	if target != cleanDir && !strings.HasPrefix(target, cleanDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal file path: %s", name)
+	}
+	return target, nil
+}
+
+// extractZipFile is a helper function that writes a single zip entry to targetPath,
+// keeping the permission bits recorded in the archive when there are any.
+func extractZipFile(file *zip.File, targetPath string) error {
+	srcFile, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	mode := file.Mode().Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+
+	out, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, srcFile); err != nil {
+		out.Close()
+		return err
+	}
+
+	// Explicitly close the file to check for write errors
+	return out.Close()
+}
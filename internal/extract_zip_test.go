@@ -0,0 +1,86 @@
+package internal_test
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestExtractZip(t *testing.T) {
+	tempDir := t.TempDir()
+	zipPath := filepath.Join(tempDir, "archive.zip")
+
+	createTestZip(t, zipPath, map[string]string{
+		"server":            "binary",
+		"config/keep.json":  "new config",
+		"server.properties": "new properties",
+	})
+
+	t.Run("success with skip", func(t *testing.T) {
+		destDir := filepath.Join(tempDir, "out")
+		if err := os.MkdirAll(destDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		propertiesPath := filepath.Join(destDir, "server.properties")
+		if err := os.WriteFile(propertiesPath, []byte("old properties"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := internal.ExtractZip(context.Background(), zipPath, destDir, func(name string) bool {
+			return name == "server.properties"
+		})
+		if err != nil {
+			t.Fatalf("ExtractZip failed: %v", err)
+		}
+
+		expectedFiles := map[string]string{
+			"server":            "binary",
+			"config/keep.json":  "new config",
+			"server.properties": "old properties", // Skipped entries keep the existing file
+		}
+		for name, want := range expectedFiles {
+			content, err := os.ReadFile(filepath.Join(destDir, filepath.FromSlash(name)))
+			if err != nil {
+				t.Fatalf("failed to read extracted file %s: %v", name, err)
+			}
+			if string(content) != want {
+				t.Errorf("content mismatch for %s: got %q, want %q", name, string(content), want)
+			}
+		}
+	})
+
+	t.Run("zip slip rejected", func(t *testing.T) {
+		evilZipPath := filepath.Join(tempDir, "evil.zip")
+		zipFile, err := os.Create(evilZipPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zipWriter := zip.NewWriter(zipFile)
+		if _, err := zipWriter.Create("../escape.txt"); err != nil {
+			t.Fatal(err)
+		}
+		zipWriter.Close()
+		zipFile.Close()
+
+		destDir := filepath.Join(tempDir, "evil")
+		if err := internal.ExtractZip(context.Background(), evilZipPath, destDir, nil); err == nil {
+			t.Fatal("expected error for entry outside destination, got nil")
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, "escape.txt")); !os.IsNotExist(err) {
+			t.Error("entry outside destination should not have been written")
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := internal.ExtractZip(ctx, zipPath, filepath.Join(tempDir, "cancelled"), nil); err == nil {
+			t.Fatal("expected error for cancelled extraction, got nil")
+		}
+	})
+}
@@ -3,10 +3,14 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// ErrNotFound is wrapped by the error FetchJSON returns for a 404 response.
+var ErrNotFound = errors.New("not found")
+
 // FetchJSON fetches JSON data from the given URL and decodes it into the provided variable with context support.
 //
 // Parameters:
@@ -15,7 +19,7 @@ import (
 //   - value: a pointer to the variable where the decoded JSON will be stored.
 //
 // Returns:
-//   - error: an error if the HTTP request fails or the JSON cannot be decoded.
+//   - error: an error if the HTTP request fails or the JSON cannot be decoded. It wraps ErrNotFound for a 404 response.
 func FetchJSON[T any](ctx context.Context, url string, value *T) error {
 	// Create a new HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -31,6 +35,9 @@ func FetchJSON[T any](ctx context.Context, url string, value *T) error {
 	defer response.Body.Close()
 
 	// Check for a successful HTTP response
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("unexpected status %d when fetching JSON from %s: %w", response.StatusCode, url, ErrNotFound)
+	}
 	if response.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
 	}
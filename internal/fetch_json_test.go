@@ -3,6 +3,7 @@ package internal_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -65,8 +66,8 @@ func TestFetchJSON(t *testing.T) {
 		defer testServer.Close()
 
 		var testJSONData testJSONStruct
-		if err := internal.FetchJSON(context.Background(), testServer.URL, &testJSONData); err == nil {
-			t.Error("expected error for 404 response, got nil")
+		if err := internal.FetchJSON(context.Background(), testServer.URL, &testJSONData); !errors.Is(err, internal.ErrNotFound) {
+			t.Errorf("expected ErrNotFound for 404 response, got: %v", err)
 		}
 	})
 
@@ -0,0 +1,334 @@
+package forgeinstaller
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+)
+
+// Options configures a native installation.
+type Options struct {
+	// JavaPath is the Java executable used to run the processor steps.
+	// If empty, it is discovered from JAVA_HOME or the PATH.
+	JavaPath string
+
+	// MinecraftServerURL is the download URL of the vanilla server jar for the installer's game version.
+	MinecraftServerURL string
+
+	// Repositories are the Maven repositories used for libraries that are neither bundled in the installer
+	// nor listed with download information.
+	Repositories []string
+
+	// Log receives progress messages. It may be nil.
+	Log func(format string, v ...any)
+}
+
+// log prints a formatted message if a log function is configured.
+func (o Options) log(format string, v ...any) {
+	if o.Log != nil {
+		o.Log(format, v...)
+	}
+}
+
+// Install performs the server installation described by a Forge or NeoForge installer jar without running the installer itself.
+// It reads install_profile.json and version.json from the jar, downloads every listed library with hash verification,
+// runs the processor steps with the configured Java executable and writes the launch files (run.sh, run.bat and user_jvm_args.txt).
+//
+// Only the modern installer format used since Minecraft 1.13 is supported.
+//
+// Parameters:
+//   - ctx: the context to control cancellation; processors are killed when it is cancelled.
+//   - installerPath: the path of the downloaded installer jar.
+//   - installDir: the server directory to install into.
+//   - options: the installation options.
+//
+// Returns:
+//   - error: an error if the installer cannot be read, a download or hash check fails, or a processor fails.
+func Install(ctx context.Context, installerPath, installDir string, options Options) error {
+	installDir, err := filepath.Abs(installDir)
+	if err != nil {
+		return err
+	}
+	installerPath, err = filepath.Abs(installerPath)
+	if err != nil {
+		return err
+	}
+
+	installerZip, err := zip.OpenReader(installerPath)
+	if err != nil {
+		return fmt.Errorf("failed to open installer: %w", err)
+	}
+	defer installerZip.Close()
+	installer := &installerZip.Reader
+
+	profile, version, err := readInstaller(installer)
+	if err != nil {
+		return err
+	}
+
+	librariesDir := filepath.Join(installDir, "libraries")
+
+	// Libraries bundled in the installer are extracted first, so that they don't need to be downloaded
+	options.log("Extracting bundled libraries...")
+	if err := extractBundledLibraries(ctx, installer, librariesDir); err != nil {
+		return err
+	}
+
+	// Download the libraries required by the processors and by the server itself
+	libraries := append(append([]library{}, profile.Libraries...), version.Libraries...)
+	pending, err := downloadLibraries(ctx, libraries, librariesDir, options)
+	if err != nil {
+		return err
+	}
+
+	// The processors patch the vanilla server jar, so it has to be downloaded first
+	minecraftJar := filepath.Join(installDir, fmt.Sprintf("minecraft_server.%s.jar", profile.Minecraft))
+	if profile.ServerJarPath != "" {
+		minecraftJar = filepath.FromSlash(strings.NewReplacer(
+			"{LIBRARY_DIR}", filepath.ToSlash(librariesDir),
+			"{MINECRAFT_VERSION}", profile.Minecraft,
+		).Replace(profile.ServerJarPath))
+		if relativePath, err := filepath.Rel(installDir, minecraftJar); err != nil || !filepath.IsLocal(relativePath) {
+			return fmt.Errorf("illegal server jar path: %s", profile.ServerJarPath)
+		}
+	}
+	if _, err := os.Stat(minecraftJar); os.IsNotExist(err) {
+		if options.MinecraftServerURL == "" {
+			return fmt.Errorf("no vanilla server download available for %s", profile.Minecraft)
+		}
+
+		options.log("Downloading vanilla server for %s...", profile.Minecraft)
+		if err := os.MkdirAll(filepath.Dir(minecraftJar), 0755); err != nil {
+			return err
+		}
+		if err := internal.Download(ctx, options.MinecraftServerURL, minecraftJar, nil); err != nil {
+			return err
+		}
+	}
+
+	// Temporary directory for data files extracted from the installer
+	tempDir, err := os.MkdirTemp("", "mcserverdl-installer-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	data, err := resolveData(installer, profile, tempDir, map[string]string{
+		"SIDE":              "server",
+		"MINECRAFT_JAR":     minecraftJar,
+		"MINECRAFT_VERSION": profile.Minecraft,
+		"ROOT":              installDir,
+		"INSTALLER":         installerPath,
+		"LIBRARY_DIR":       librariesDir,
+	}, librariesDir)
+	if err != nil {
+		return err
+	}
+
+	if err := runProcessors(ctx, profile.Processors, data, librariesDir, installDir, pending, options); err != nil {
+		return err
+	}
+
+	return writeLaunchFiles(installer, profile, version, librariesDir, installDir, options)
+}
+
+// extractBundledLibraries extracts the "maven/" directory of the installer into the libraries directory.
+func extractBundledLibraries(ctx context.Context, installer *zip.Reader, librariesDir string) error {
+	for _, file := range installer.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !strings.HasPrefix(file.Name, "maven/") || file.FileInfo().IsDir() {
+			continue
+		}
+
+		relativePath := strings.TrimPrefix(file.Name, "maven/")
+		targetPath, err := internal.SafeJoin(librariesDir, relativePath)
+		if err != nil {
+			return fmt.Errorf("bundled library: %w", err)
+		}
+		if err := extractEntry(file, targetPath); err != nil {
+			return fmt.Errorf("failed to extract bundled library '%s': %w", relativePath, err)
+		}
+	}
+
+	return nil
+}
+
+// extractEntry writes a single zip entry to targetPath, creating parent directories as needed.
+func extractEntry(file *zip.File, targetPath string) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	source, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	out, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, source); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// downloadLibraries downloads every library that is not yet present with a matching hash.
+// Libraries listed with an empty download URL are produced by the processors (e.g., the patched server jar),
+// so they are left alone like the official installer does, unless they were extracted from the installer.
+// Libraries listed without any download information are resolved against the configured repositories.
+//
+// It returns the paths of the libraries left for the processors, mapped to their names.
+func downloadLibraries(ctx context.Context, libraries []library, librariesDir string, options Options) (map[string]string, error) {
+	seen := map[string]struct{}{}
+	pending := map[string]string{}
+
+	for _, lib := range libraries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		downloadInfo := lib.Downloads.Artifact
+		relativePath := ""
+		if downloadInfo != nil && downloadInfo.Path != "" {
+			relativePath = downloadInfo.Path
+		} else {
+			coordinate, err := maven.ParseCoordinate(lib.Name)
+			if err != nil {
+				return nil, err
+			}
+			relativePath = coordinate.Path()
+		}
+
+		if _, exist := seen[relativePath]; exist {
+			continue
+		}
+		seen[relativePath] = struct{}{}
+
+		targetPath, err := internal.SafeJoin(librariesDir, relativePath)
+		if err != nil {
+			return nil, fmt.Errorf("library %s: %w", lib.Name, err)
+		}
+		expectedSHA1 := ""
+		if downloadInfo != nil {
+			expectedSHA1 = downloadInfo.SHA1
+		}
+
+		// Skip libraries that are already present and intact
+		if _, err := os.Stat(targetPath); err == nil {
+			if expectedSHA1 == "" || internal.VerifyFile(targetPath, "sha1", expectedSHA1) == nil {
+				if err := internal.Touch(targetPath); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		// Libraries with an empty URL are outputs of the processors
+		if downloadInfo != nil && downloadInfo.URL == "" {
+			options.log("Library %s will be produced by the processors", lib.Name)
+			pending[targetPath] = lib.Name
+			continue
+		}
+
+		options.log("Downloading library %s...", lib.Name)
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return nil, err
+		}
+
+		if downloadInfo == nil {
+			// Libraries without download information are resolved against the configured repositories
+			if len(options.Repositories) == 0 {
+				return nil, fmt.Errorf("library %s is not bundled in the installer and has no download information", lib.Name)
+			}
+			coordinate, err := maven.ParseCoordinate(lib.Name)
+			if err != nil {
+				return nil, err
+			}
+			if err := maven.NewClient(options.Repositories...).Download(ctx, coordinate, targetPath, nil); err != nil {
+				return nil, fmt.Errorf("failed to download library %s: %w", lib.Name, err)
+			}
+			continue
+		}
+
+		if err := internal.Download(ctx, downloadInfo.URL, targetPath, nil); err != nil {
+			return nil, fmt.Errorf("failed to download library %s: %w", lib.Name, err)
+		}
+
+		// Libraries listed without a hash are checked against the repository's checksum files instead
+		verifyErr := maven.VerifyChecksum(ctx, downloadInfo.URL, targetPath)
+		if expectedSHA1 != "" {
+			verifyErr = internal.VerifyFile(targetPath, "sha1", expectedSHA1)
+		}
+		if verifyErr != nil {
+			os.Remove(targetPath)
+			return nil, fmt.Errorf("failed to verify library %s: %w", lib.Name, verifyErr)
+		}
+	}
+
+	return pending, nil
+}
+
+// resolveData resolves the server-side values of the install profile's data entries.
+// Values in brackets are Maven coordinates, values in single quotes are literals,
+// and values starting with "/" are files extracted from the installer jar.
+func resolveData(installer *zip.Reader, profile *installProfile, tempDir string, data map[string]string, librariesDir string) (map[string]string, error) {
+	for key, entry := range profile.Data {
+		value := entry.Server
+
+		switch {
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			path, err := libraryPath(librariesDir, value[1:len(value)-1])
+			if err != nil {
+				return nil, err
+			}
+			data[key] = path
+		case strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2:
+			data[key] = value[1 : len(value)-1]
+		case strings.HasPrefix(value, "/"):
+			entryName := strings.TrimPrefix(value, "/")
+			file, err := findEntry(installer, entryName)
+			if err != nil {
+				return nil, err
+			}
+
+			targetPath, err := internal.SafeJoin(tempDir, entryName)
+			if err != nil {
+				return nil, fmt.Errorf("installer data %s: %w", key, err)
+			}
+			if err := extractEntry(file, targetPath); err != nil {
+				return nil, fmt.Errorf("failed to extract installer data '%s': %w", entryName, err)
+			}
+			data[key] = targetPath
+		default:
+			data[key] = value
+		}
+	}
+
+	return data, nil
+}
+
+// findEntry returns the zip entry with the given name.
+func findEntry(archive *zip.Reader, name string) (*zip.File, error) {
+	for _, file := range archive.File {
+		if file.Name == name {
+			return file, nil
+		}
+	}
+	return nil, fmt.Errorf("file %s not found in installer", name)
+}
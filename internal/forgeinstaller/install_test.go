@@ -0,0 +1,292 @@
+package forgeinstaller_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal/forgeinstaller"
+)
+
+// createZip builds a zip archive in memory from a map of entry names to contents.
+func createZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for name, content := range files {
+		f, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", name, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write entry %s: %v", name, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func sha1Hex(content string) string {
+	sum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake java executable is a shell script")
+	}
+
+	const libraryContent = "library jar"
+	const patchedContent = "patched server"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/maven/org/example/lib/1.0/lib-1.0.jar":
+			fmt.Fprint(w, libraryContent)
+		case "/server.jar":
+			fmt.Fprint(w, "vanilla server")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	processorJar := createZip(t, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nMain-Class: org.example.Patcher\r\n",
+	})
+
+	installProfile := fmt.Sprintf(`{
+		"spec": 1,
+		"version": "1.20.1-forge-47.2.0",
+		"minecraft": "1.20.1",
+		"json": "/version.json",
+		"serverJarPath": "{LIBRARY_DIR}/net/minecraft/server/{MINECRAFT_VERSION}/server-{MINECRAFT_VERSION}.jar",
+		"data": {
+			"PATCHED": {"client": "[net.minecraft:client:1.20.1:patched]", "server": "[net.minecraft:server:1.20.1:patched]"},
+			"PATCHED_SHA": {"client": "'0000'", "server": "'%s'"},
+			"BINPATCH": {"client": "/data/client.lzma", "server": "/data/server.lzma"}
+		},
+		"processors": [
+			{"sides": ["client"], "jar": "org.example:missing:1.0", "args": []},
+			{
+				"jar": "org.example:patcher:1.0",
+				"classpath": ["org.example:lib:1.0"],
+				"args": ["--input", "{MINECRAFT_JAR}", "--patch", "{BINPATCH}", "--output", "{PATCHED}"],
+				"outputs": {"{PATCHED}": "{PATCHED_SHA}"}
+			}
+		],
+		"libraries": [
+			{"name": "org.example:patcher:1.0", "downloads": {"artifact": {"path": "org/example/patcher/1.0/patcher-1.0.jar", "url": ""}}}
+		]
+	}`, sha1Hex(patchedContent))
+
+	versionJSON := fmt.Sprintf(`{
+		"id": "1.20.1-forge-47.2.0",
+		"mainClass": "org.example.Main",
+		"libraries": [
+			{"name": "org.example:lib:1.0", "downloads": {"artifact": {"path": "org/example/lib/1.0/lib-1.0.jar", "url": "%s/maven/org/example/lib/1.0/lib-1.0.jar", "sha1": "%s"}}}
+		],
+		"arguments": {"jvm": ["-DlibraryDirectory=${library_directory}"], "game": ["--launchTarget", "forgeserver"]}
+	}`, server.URL, sha1Hex(libraryContent))
+
+	installer := createZip(t, map[string]string{
+		"install_profile.json": installProfile,
+		"version.json":         versionJSON,
+		"data/server.lzma":     "binary patch",
+		"data/run.sh":          "#!/usr/bin/env sh\njava @user_jvm_args.txt @libraries/net/minecraftforge/forge/1.20.1-47.2.0/unix_args.txt \"$@\"\n",
+		"maven/org/example/patcher/1.0/patcher-1.0.jar": string(processorJar),
+	})
+
+	// The fake java executable writes the file following "--output", like a real patcher would
+	tempDir := t.TempDir()
+	javaPath := filepath.Join(tempDir, "java")
+	javaScript := fmt.Sprintf("#!/bin/sh\nwhile [ $# -gt 0 ]; do if [ \"$1\" = \"--output\" ]; then mkdir -p \"$(dirname \"$2\")\"; printf '%s' > \"$2\"; fi; shift; done\necho patched\n", patchedContent)
+	if err := os.WriteFile(javaPath, []byte(javaScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	installDir := filepath.Join(tempDir, "server")
+	if err := os.MkdirAll(installDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	installerPath := filepath.Join(installDir, "installer.jar")
+	if err := os.WriteFile(installerPath, installer, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs []string
+	err := forgeinstaller.Install(context.Background(), installerPath, installDir, forgeinstaller.Options{
+		JavaPath:           javaPath,
+		MinecraftServerURL: server.URL + "/server.jar",
+		Log: func(format string, v ...any) {
+			logs = append(logs, fmt.Sprintf(format, v...))
+		},
+	})
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	expectedFiles := map[string]string{
+		"libraries/org/example/lib/1.0/lib-1.0.jar":                       libraryContent,
+		"libraries/net/minecraft/server/1.20.1/server-1.20.1.jar":         "vanilla server",
+		"libraries/net/minecraft/server/1.20.1/server-1.20.1-patched.jar": patchedContent,
+		"libraries/net/minecraftforge/forge/1.20.1-47.2.0/unix_args.txt":  "-DlibraryDirectory=libraries\n-cp\nlibraries/org/example/lib/1.0/lib-1.0.jar\norg.example.Main\n--launchTarget\nforgeserver\n",
+		"libraries/org/example/patcher/1.0/patcher-1.0.jar":               string(processorJar),
+	}
+	for name, want := range expectedFiles {
+		content, err := os.ReadFile(filepath.Join(installDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("expected file %s: %v", name, err)
+			continue
+		}
+		if string(content) != want {
+			t.Errorf("content mismatch for %s: got %q, want %q", name, content, want)
+		}
+	}
+
+	for _, name := range []string{"run.sh", "run.bat", "user_jvm_args.txt"} {
+		if _, err := os.Stat(filepath.Join(installDir, name)); err != nil {
+			t.Errorf("expected launch file %s: %v", name, err)
+		}
+	}
+
+	if !strings.Contains(strings.Join(logs, "\n"), "patched") {
+		t.Error("expected processor output to be streamed to the log")
+	}
+
+	t.Run("processors skipped when outputs are valid", func(t *testing.T) {
+		err := forgeinstaller.Install(context.Background(), installerPath, installDir, forgeinstaller.Options{
+			JavaPath: filepath.Join(tempDir, "missing-java"),
+		})
+		if err != nil {
+			t.Fatalf("expected up-to-date install to succeed without java, got: %v", err)
+		}
+	})
+
+	t.Run("library hash mismatch", func(t *testing.T) {
+		otherDir := filepath.Join(tempDir, "mismatch")
+		if err := os.MkdirAll(otherDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		badInstaller := createZip(t, map[string]string{
+			"install_profile.json": `{"spec":1,"minecraft":"1.20.1","json":"/version.json"}`,
+			"version.json":         fmt.Sprintf(`{"id":"x","libraries":[{"name":"org.example:lib:1.0","downloads":{"artifact":{"path":"org/example/lib/1.0/lib-1.0.jar","url":"%s/maven/org/example/lib/1.0/lib-1.0.jar","sha1":"0000"}}}]}`, server.URL),
+		})
+		badPath := filepath.Join(otherDir, "installer.jar")
+		if err := os.WriteFile(badPath, badInstaller, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := forgeinstaller.Install(context.Background(), badPath, otherDir, forgeinstaller.Options{}); err == nil {
+			t.Fatal("expected error for library hash mismatch, got nil")
+		}
+		if _, err := os.Stat(filepath.Join(otherDir, "libraries/org/example/lib/1.0/lib-1.0.jar")); !os.IsNotExist(err) {
+			t.Error("library with mismatching hash should have been removed")
+		}
+	})
+
+	t.Run("libraries without a download URL", func(t *testing.T) {
+		const produced = `{"name":"org.example:produced:1.0","downloads":{"artifact":{"path":"org/example/produced/1.0/produced-1.0.jar","url":""}}}`
+		installers := map[string]struct {
+			profile string
+			wantErr string
+		}{
+			"not needed by a processor": {
+				profile: `{"spec":1,"minecraft":"1.20.1","json":"/version.json"}`,
+			},
+			"needed by a processor": {
+				profile: `{"spec":1,"minecraft":"1.20.1","json":"/version.json","processors":[{"jar":"org.example:patcher:1.0","classpath":["org.example:produced:1.0"],"args":[]}],
+					"libraries":[{"name":"org.example:patcher:1.0","downloads":{"artifact":{"path":"org/example/patcher/1.0/patcher-1.0.jar","url":""}}}]}`,
+				wantErr: "needs library org.example:produced:1.0",
+			},
+		}
+
+		for name, installer := range installers {
+			t.Run(name, func(t *testing.T) {
+				otherDir := t.TempDir()
+				emptyURLPath := filepath.Join(otherDir, "installer.jar")
+				files := map[string]string{
+					"install_profile.json": installer.profile,
+					"version.json":         `{"id":"x","libraries":[` + produced + `]}`,
+					"maven/org/example/patcher/1.0/patcher-1.0.jar": string(processorJar),
+				}
+				if err := os.WriteFile(emptyURLPath, createZip(t, files), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				err := forgeinstaller.Install(context.Background(), emptyURLPath, otherDir, forgeinstaller.Options{JavaPath: javaPath, MinecraftServerURL: server.URL + "/server.jar"})
+				if installer.wantErr == "" && err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				if installer.wantErr != "" && (err == nil || !strings.Contains(err.Error(), installer.wantErr)) {
+					t.Fatalf("expected an error containing %q, got: %v", installer.wantErr, err)
+				}
+			})
+		}
+	})
+
+	t.Run("entries escaping the install directory", func(t *testing.T) {
+		installers := map[string]map[string]string{
+			"bundled library": {
+				"install_profile.json": `{"spec":1,"minecraft":"1.20.1","json":"/version.json"}`,
+				"version.json":         `{"id":"x"}`,
+				"maven/../../evil.jar": "evil",
+			},
+			"installer data": {
+				"install_profile.json": `{"spec":1,"minecraft":"1.20.1","json":"/version.json","data":{"EVIL":{"client":"","server":"/../../evil.jar"}}}`,
+				"version.json":         `{"id":"x"}`,
+				"../../evil.jar":       "evil",
+			},
+			"library path": {
+				"install_profile.json": `{"spec":1,"minecraft":"1.20.1","json":"/version.json"}`,
+				"version.json":         fmt.Sprintf(`{"id":"x","libraries":[{"name":"org.example:lib:1.0","downloads":{"artifact":{"path":"../../evil.jar","url":"%s/maven/org/example/lib/1.0/lib-1.0.jar"}}}]}`, server.URL),
+			},
+		}
+
+		for name, files := range installers {
+			t.Run(name, func(t *testing.T) {
+				rootDir := t.TempDir()
+				otherDir := filepath.Join(rootDir, "a", "server")
+				if err := os.MkdirAll(otherDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				slipPath := filepath.Join(otherDir, "installer.jar")
+				if err := os.WriteFile(slipPath, createZip(t, files), 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				err := forgeinstaller.Install(context.Background(), slipPath, otherDir, forgeinstaller.Options{MinecraftServerURL: server.URL + "/server.jar"})
+				if err == nil || !strings.Contains(err.Error(), "illegal file path") {
+					t.Fatalf("expected an illegal file path error, got: %v", err)
+				}
+				if _, err := os.Stat(filepath.Join(rootDir, "evil.jar")); !os.IsNotExist(err) {
+					t.Error("an entry was written outside of the install directory")
+				}
+			})
+		}
+	})
+
+	t.Run("legacy installer", func(t *testing.T) {
+		legacyPath := filepath.Join(tempDir, "legacy.jar")
+		legacy := createZip(t, map[string]string{"install_profile.json": `{"install":{"path":"net.minecraftforge:forge:1.12.2-14.23.5.2859"},"versionInfo":{}}`})
+		if err := os.WriteFile(legacyPath, legacy, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := forgeinstaller.Install(context.Background(), legacyPath, tempDir, forgeinstaller.Options{}); err == nil {
+			t.Fatal("expected error for legacy installer, got nil")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package forgeinstaller
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+)
+
+// argsFilePattern finds the arguments files referenced by the run scripts
+// (e.g., "@libraries/net/minecraftforge/forge/1.20.1-47.2.0/unix_args.txt").
+var argsFilePattern = regexp.MustCompile(`@(libraries[/\\][^\s"]+_args\.txt)`)
+
+// writeLaunchFiles writes run.sh, run.bat and user_jvm_args.txt into the install directory.
+// The scripts shipped in the installer's "data/" directory are used when available; otherwise they are generated.
+func writeLaunchFiles(installer *zip.Reader, profile *installProfile, version *versionJSON, librariesDir, installDir string, options Options) error {
+	// Copy the server launcher jar (e.g., the shim jar) into the install directory
+	launcherJar := ""
+	if profile.Path != "" {
+		sourcePath, err := libraryPath(librariesDir, profile.Path)
+		if err != nil {
+			return err
+		}
+		launcherJar = filepath.Base(sourcePath)
+		if err := copyFile(sourcePath, filepath.Join(installDir, launcherJar)); err != nil {
+			return fmt.Errorf("failed to copy server launcher: %w", err)
+		}
+	}
+
+	// user_jvm_args.txt is edited by users, so an existing file is never replaced
+	jvmArgsPath := filepath.Join(installDir, "user_jvm_args.txt")
+	if _, err := os.Stat(jvmArgsPath); os.IsNotExist(err) {
+		content, err := readEntry(installer, "data/user_jvm_args.txt")
+		if err != nil {
+			content = []byte("# Xmx and Xms set the maximum and minimum RAM usage, respectively.\n# Uncomment the next line to set it.\n# -Xmx4G\n")
+		}
+		if err := os.WriteFile(jvmArgsPath, content, 0644); err != nil {
+			return err
+		}
+	}
+
+	for _, script := range []string{"run.sh", "run.bat"} {
+		content, err := readEntry(installer, "data/"+script)
+		if err != nil {
+			content = []byte(generateScript(script, launcherJar, version.ID))
+		}
+
+		if err := os.WriteFile(filepath.Join(installDir, script), content, 0755); err != nil {
+			return err
+		}
+
+		// Generate the arguments files referenced by the script if the processors did not produce them
+		for _, match := range argsFilePattern.FindAllStringSubmatch(string(content), -1) {
+			argsPath, err := internal.SafeJoin(installDir, strings.ReplaceAll(match[1], `\`, "/"))
+			if err != nil {
+				return fmt.Errorf("%s: %w", script, err)
+			}
+			if _, err := os.Stat(argsPath); err == nil {
+				continue
+			}
+
+			separator := ":"
+			if strings.HasPrefix(filepath.Base(argsPath), "win") {
+				separator = ";"
+			}
+			if err := os.MkdirAll(filepath.Dir(argsPath), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(argsPath, []byte(generateArgs(version, separator)), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	options.log("Wrote run.sh, run.bat and user_jvm_args.txt")
+	return nil
+}
+
+// generateScript generates a run script for installers that don't ship one.
+// It launches the server launcher jar if there is one, or the generated arguments files otherwise.
+func generateScript(script, launcherJar, versionID string) string {
+	argsDir := path.Join("libraries", "mcserverdl", versionID)
+
+	if script == "run.bat" {
+		if launcherJar != "" {
+			return fmt.Sprintf("@echo off\r\njava @user_jvm_args.txt -jar %s %%*\r\npause\r\n", launcherJar)
+		}
+		return fmt.Sprintf("@echo off\r\njava @user_jvm_args.txt @%s %%*\r\npause\r\n", strings.ReplaceAll(path.Join(argsDir, "win_args.txt"), "/", `\`))
+	}
+
+	if launcherJar != "" {
+		return fmt.Sprintf("#!/usr/bin/env sh\njava @user_jvm_args.txt -jar %s \"$@\"\n", launcherJar)
+	}
+	return fmt.Sprintf("#!/usr/bin/env sh\njava @user_jvm_args.txt @%s \"$@\"\n", path.Join(argsDir, "unix_args.txt"))
+}
+
+// generateArgs generates the content of a Java arguments file from the version JSON.
+// Only plain string arguments are used; rule-based arguments only apply to the client.
+func generateArgs(version *versionJSON, separator string) string {
+	replacer := strings.NewReplacer(
+		"${library_directory}", "libraries",
+		"${classpath_separator}", separator,
+		"${version_name}", version.ID,
+	)
+
+	var lines []string
+	for _, raw := range version.Arguments.JVM {
+		var arg string
+		if json.Unmarshal(raw, &arg) == nil {
+			lines = append(lines, quoteArg(replacer.Replace(arg)))
+		}
+	}
+
+	classpath := make([]string, 0, len(version.Libraries))
+	for _, lib := range version.Libraries {
+		if lib.Downloads.Artifact != nil && lib.Downloads.Artifact.Path != "" {
+			classpath = append(classpath, "libraries/"+lib.Downloads.Artifact.Path)
+		} else if coordinate, err := maven.ParseCoordinate(lib.Name); err == nil {
+			classpath = append(classpath, "libraries/"+coordinate.Path())
+		}
+	}
+	if len(classpath) > 0 {
+		lines = append(lines, "-cp", quoteArg(strings.Join(classpath, separator)))
+	}
+
+	lines = append(lines, version.MainClass)
+	for _, raw := range version.Arguments.Game {
+		var arg string
+		if json.Unmarshal(raw, &arg) == nil {
+			lines = append(lines, quoteArg(replacer.Replace(arg)))
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// quoteArg quotes an argument file entry if it contains whitespace.
+func quoteArg(arg string) string {
+	if strings.ContainsAny(arg, " \t") {
+		return `"` + strings.ReplaceAll(arg, `\`, `\\`) + `"`
+	}
+	return arg
+}
+
+// copyFile copies a file from source to target, replacing target if it exists.
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
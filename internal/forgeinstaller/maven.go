@@ -0,0 +1,16 @@
+package forgeinstaller
+
+import (
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+)
+
+// libraryPath returns the absolute path of a library inside the libraries directory.
+// Coordinates whose path would escape the libraries directory are rejected.
+func libraryPath(librariesDir, coordinate string) (string, error) {
+	c, err := maven.ParseCoordinate(coordinate)
+	if err != nil {
+		return "", err
+	}
+	return internal.SafeJoin(librariesDir, c.Path())
+}
@@ -0,0 +1,207 @@
+package forgeinstaller
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// runProcessors runs the server-side processors of the install profile in order.
+// Processors whose outputs already exist with the expected hashes are skipped.
+// pending maps the paths of the libraries left for the processors to produce (see downloadLibraries) to their names.
+func runProcessors(ctx context.Context, processors []processor, data map[string]string, librariesDir, installDir string, pending map[string]string, options Options) error {
+	// The Java executable is only looked up once a processor actually has to run
+	java := ""
+
+	for index, proc := range processors {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Processors without sides run on both the client and the server
+		if len(proc.Sides) > 0 && !slices.Contains(proc.Sides, "server") {
+			continue
+		}
+
+		outputs, err := resolveOutputs(proc.Outputs, data, librariesDir)
+		if err != nil {
+			return err
+		}
+		if len(outputs) > 0 && outputsValid(outputs) {
+			options.log("Processor %d/%d (%s) is up to date", index+1, len(processors), proc.Jar)
+			for path := range outputs {
+				if err := internal.Touch(path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		jarPath, err := libraryPath(librariesDir, proc.Jar)
+		if err != nil {
+			return err
+		}
+
+		classpath := []string{jarPath}
+		for _, coordinate := range proc.Classpath {
+			path, err := libraryPath(librariesDir, coordinate)
+			if err != nil {
+				return err
+			}
+			classpath = append(classpath, path)
+		}
+
+		var args []string
+		for _, arg := range proc.Args {
+			resolved, err := resolveArgument(arg, data, librariesDir)
+			if err != nil {
+				return fmt.Errorf("processor %s: %w", proc.Jar, err)
+			}
+			args = append(args, resolved)
+		}
+
+		// Libraries without a download URL are produced by earlier processors and must exist by now
+		for _, input := range slices.Concat(classpath, args) {
+			name, ok := pending[input]
+			if _, isOutput := outputs[input]; !ok || isOutput {
+				continue
+			}
+			if _, err := os.Stat(input); os.IsNotExist(err) {
+				return fmt.Errorf("processor %s needs library %s, which is not bundled in the installer, has no download URL and was not produced by an earlier processor", proc.Jar, name)
+			}
+		}
+
+		mainClass, err := jarMainClass(jarPath)
+		if err != nil {
+			return fmt.Errorf("processor %s: %w", proc.Jar, err)
+		}
+		args = append([]string{"-cp", strings.Join(classpath, string(os.PathListSeparator)), mainClass}, args...)
+
+		if java == "" {
+			java, err = internal.FindJava(options.JavaPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		options.log("Running processor %d/%d (%s)...", index+1, len(processors), proc.Jar)
+
+		output := &internal.LineWriter{Log: options.Log}
+		cmd := exec.CommandContext(ctx, java, args...)
+		cmd.Dir = installDir
+		cmd.Stdout = output
+		cmd.Stderr = output
+		err = cmd.Run()
+		output.Flush()
+		if err != nil {
+			return fmt.Errorf("processor %s failed: %w", proc.Jar, err)
+		}
+
+		// Verify that the processor produced the expected files
+		for path, expectedSHA1 := range outputs {
+			if err := internal.VerifyFile(path, "sha1", expectedSHA1); err != nil {
+				return fmt.Errorf("processor %s produced an invalid output: %w", proc.Jar, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveArgument resolves a processor argument.
+// Arguments in brackets are Maven coordinates; other arguments have their "{KEY}" tokens replaced with data values.
+func resolveArgument(arg string, data map[string]string, librariesDir string) (string, error) {
+	if strings.HasPrefix(arg, "[") && strings.HasSuffix(arg, "]") {
+		return libraryPath(librariesDir, arg[1:len(arg)-1])
+	}
+
+	var result strings.Builder
+	rest := arg
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			result.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			result.WriteString(rest)
+			break
+		}
+		end += start
+
+		key := rest[start+1 : end]
+		value, ok := data[key]
+		if !ok {
+			return "", fmt.Errorf("unknown data key %q in argument %q", key, arg)
+		}
+
+		result.WriteString(rest[:start])
+		result.WriteString(value)
+		rest = rest[end+1:]
+	}
+
+	return result.String(), nil
+}
+
+// resolveOutputs resolves the output paths and expected hashes of a processor.
+func resolveOutputs(outputs map[string]string, data map[string]string, librariesDir string) (map[string]string, error) {
+	resolved := make(map[string]string, len(outputs))
+	for key, value := range outputs {
+		path, err := resolveArgument(key, data, librariesDir)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := resolveArgument(value, data, librariesDir)
+		if err != nil {
+			return nil, err
+		}
+		resolved[path] = strings.Trim(hash, "'")
+	}
+	return resolved, nil
+}
+
+// outputsValid reports whether every output file exists with the expected hash.
+func outputsValid(outputs map[string]string) bool {
+	for path, expectedSHA1 := range outputs {
+		if internal.VerifyFile(path, "sha1", expectedSHA1) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// jarMainClass reads the Main-Class attribute from the manifest of a jar.
+func jarMainClass(jarPath string) (string, error) {
+	jar, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", filepath.Base(jarPath), err)
+	}
+	defer jar.Close()
+
+	manifest, err := jar.Open("META-INF/MANIFEST.MF")
+	if err != nil {
+		return "", fmt.Errorf("failed to open manifest of %s: %w", filepath.Base(jarPath), err)
+	}
+	defer manifest.Close()
+
+	scanner := bufio.NewScanner(manifest)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "Main-Class:"); ok {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no Main-Class in manifest of %s", filepath.Base(jarPath))
+}
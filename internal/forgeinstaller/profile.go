@@ -0,0 +1,115 @@
+package forgeinstaller
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// artifact describes a downloadable library file.
+type artifact struct {
+	Path string `json:"path"`
+	URL  string `json:"url"`
+	SHA1 string `json:"sha1"`
+	Size int64  `json:"size"`
+}
+
+// library describes a library listed in install_profile.json or version.json.
+type library struct {
+	Name      string `json:"name"`
+	Downloads struct {
+		Artifact *artifact `json:"artifact"`
+	} `json:"downloads"`
+}
+
+// processor describes a post-processing step of the installation.
+type processor struct {
+	Sides     []string          `json:"sides"`
+	Jar       string            `json:"jar"`
+	Classpath []string          `json:"classpath"`
+	Args      []string          `json:"args"`
+	Outputs   map[string]string `json:"outputs"`
+}
+
+// installProfile is the modern (spec 1) install_profile.json used since Minecraft 1.13.
+type installProfile struct {
+	Spec          int    `json:"spec"`
+	Version       string `json:"version"`
+	Path          string `json:"path"`
+	Minecraft     string `json:"minecraft"`
+	JSON          string `json:"json"`
+	ServerJarPath string `json:"serverJarPath"`
+	Data          map[string]struct {
+		Client string `json:"client"`
+		Server string `json:"server"`
+	} `json:"data"`
+	Processors []processor `json:"processors"`
+	Libraries  []library   `json:"libraries"`
+
+	// Install is only present in the legacy profile format used before Minecraft 1.13.
+	Install json.RawMessage `json:"install"`
+}
+
+// versionJSON is the launcher profile embedded in the installer.
+type versionJSON struct {
+	ID        string    `json:"id"`
+	MainClass string    `json:"mainClass"`
+	Libraries []library `json:"libraries"`
+	Arguments struct {
+		Game []json.RawMessage `json:"game"`
+		JVM  []json.RawMessage `json:"jvm"`
+	} `json:"arguments"`
+}
+
+// readInstaller reads install_profile.json and the version JSON referenced by it from an installer jar.
+func readInstaller(installer *zip.Reader) (*installProfile, *versionJSON, error) {
+	var profile installProfile
+	if err := readJSONEntry(installer, "install_profile.json", &profile); err != nil {
+		return nil, nil, err
+	}
+
+	if profile.Install != nil {
+		return nil, nil, errors.New("legacy installer format (before Minecraft 1.13) is not supported by the native installer, run it with java -jar installer.jar --installServer instead")
+	}
+
+	versionPath := strings.TrimPrefix(profile.JSON, "/")
+	if versionPath == "" {
+		versionPath = "version.json"
+	}
+
+	var version versionJSON
+	if err := readJSONEntry(installer, versionPath, &version); err != nil {
+		return nil, nil, err
+	}
+
+	return &profile, &version, nil
+}
+
+// readJSONEntry decodes a JSON file stored in a zip archive.
+func readJSONEntry(archive *zip.Reader, name string, value any) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to open %s in installer: %w", name, err)
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode %s in installer: %w", name, err)
+	}
+
+	return nil
+}
+
+// readEntry returns the content of a file stored in a zip archive.
+func readEntry(archive *zip.Reader, name string) ([]byte, error) {
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
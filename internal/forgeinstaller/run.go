@@ -0,0 +1,55 @@
+package forgeinstaller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// RunInstaller runs "java -jar <installer> --installServer" in the install directory.
+// The installer output is streamed to log line by line, and the process is killed if ctx is cancelled.
+// On success, the installer jar and the installer log are removed.
+//
+// Parameters:
+//   - ctx: the context to control cancellation.
+//   - installerPath: the path of the downloaded installer jar.
+//   - installDir: the server directory to install into.
+//   - javaPath: the Java executable; if empty, it is discovered from JAVA_HOME or the PATH.
+//   - log: receives the installer output; it may be nil.
+//
+// Returns:
+//   - error: an error if no Java executable is found or the installer fails.
+func RunInstaller(ctx context.Context, installerPath, installDir, javaPath string, log func(format string, v ...any)) error {
+	java, err := internal.FindJava(javaPath)
+	if err != nil {
+		return err
+	}
+
+	installerPath, err = filepath.Abs(installerPath)
+	if err != nil {
+		return err
+	}
+
+	output := &internal.LineWriter{Log: log}
+	cmd := exec.CommandContext(ctx, java, "-jar", installerPath, "--installServer")
+	cmd.Dir = installDir
+	cmd.Stdout = output
+	cmd.Stderr = output
+	err = cmd.Run()
+	output.Flush()
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err != nil {
+		return fmt.Errorf("installer failed: %w", err)
+	}
+
+	os.Remove(installerPath)
+	os.Remove(installerPath + ".log")
+	return nil
+}
@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// GitHubAPIURL is the base URL of the public GitHub REST API.
+const GitHubAPIURL = "https://api.github.com"
+
+// GitHubAsset describes a single file attached to a GitHub release.
+type GitHubAsset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// GitHubRelease describes a single GitHub release as returned by the REST API.
+type GitHubRelease struct {
+	TagName    string        `json:"tag_name"`
+	Name       string        `json:"name"`
+	Draft      bool          `json:"draft"`
+	Prerelease bool          `json:"prerelease"`
+	Assets     []GitHubAsset `json:"assets"`
+}
+
+// FetchGitHubReleases fetches the releases of a GitHub repository with context support.
+// The pages of the listing are followed through the "next" links of the Link header.
+// Draft releases are skipped because their assets cannot be downloaded anonymously.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - apiURL: the base URL of the GitHub API (usually GitHubAPIURL).
+//   - repository: the repository in "owner/name" form (e.g., "Winds-Studio/Leaf").
+//
+// Returns:
+//   - []GitHubRelease: the published releases of the repository, newest first.
+//   - error: an error if an HTTP request fails or the JSON cannot be decoded.
+func FetchGitHubReleases(ctx context.Context, apiURL, repository string) ([]GitHubRelease, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", strings.TrimSuffix(apiURL, "/"), repository)
+
+	var releases []GitHubRelease
+	for url != "" {
+		var releaseData []GitHubRelease
+		next, err := fetchGitHubPage(ctx, url, &releaseData)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, release := range releaseData {
+			if !release.Draft {
+				releases = append(releases, release)
+			}
+		}
+		url = next
+	}
+
+	return releases, nil
+}
+
+// fetchGitHubPage fetches a page of a GitHub API listing and returns the URL of the next page, or "" on the last page.
+func fetchGitHubPage[T any](ctx context.Context, url string, value *T) (string, error) {
+	// Create a new HTTP request with context
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	// Send HTTP GET request
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch JSON from %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	// Check for a successful HTTP response
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+	}
+
+	// Decode the JSON response into the provided variable
+	if err := json.NewDecoder(response.Body).Decode(value); err != nil {
+		return "", fmt.Errorf("failed to decode JSON from %s: %w", url, err)
+	}
+
+	return nextLink(response.Header.Get("Link")), nil
+}
+
+// nextLink returns the URL of the "next" relation of a Link header (e.g., `<https://...?page=2>; rel="next"`).
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if name, value, _ := strings.Cut(strings.TrimSpace(param), "="); name == "rel" && slices.Contains(strings.Fields(strings.Trim(value, `"`)), "next") {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestFetchGitHubReleases(t *testing.T) {
+	const releasesJSON = `[
+		{"tag_name":"v2","draft":true,"assets":[]},
+		{"tag_name":"v1","prerelease":true,"assets":[{"name":"server-1.21.4.jar","size":3,"browser_download_url":"https://example.com/server-1.21.4.jar"}]}
+	]`
+
+	const secondPageJSON = `[{"tag_name":"v0","assets":[{"name":"server-1.21.3.jar","size":3,"browser_download_url":"https://example.com/server-1.21.3.jar"}]}]`
+
+	var testServer *httptest.Server
+	testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/server/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/server/releases?per_page=100&page=1>; rel="prev", <%s/repos/owner/server/releases?per_page=100&page=1>; rel="first"`, testServer.URL, testServer.URL))
+			fmt.Fprint(w, secondPageJSON)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/server/releases?per_page=100&page=2>; rel="next", <%s/repos/owner/server/releases?per_page=100&page=2>; rel="last"`, testServer.URL, testServer.URL))
+		fmt.Fprint(w, releasesJSON)
+	}))
+	defer testServer.Close()
+
+	t.Run("success skips drafts and follows pages", func(t *testing.T) {
+		releases, err := internal.FetchGitHubReleases(context.Background(), testServer.URL, "owner/server")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(releases) != 2 {
+			t.Fatalf("expected 2 releases, got %d", len(releases))
+		}
+		if releases[0].TagName != "v1" || len(releases[0].Assets) != 1 {
+			t.Errorf("unexpected release: %+v", releases[0])
+		}
+		if releases[1].TagName != "v0" {
+			t.Errorf("expected the release of the second page, got: %+v", releases[1])
+		}
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		if _, err := internal.FetchGitHubReleases(context.Background(), testServer.URL, "owner/missing"); err == nil {
+			t.Error("expected error for missing repository, got nil")
+		}
+	})
+}
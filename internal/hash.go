@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// NewHash returns a new hash.Hash for the given algorithm name ("md5", "sha1", "sha256" or "sha512").
+func NewHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	}
+}
+
+// HashFile computes the hex-encoded digest of a file with the given algorithm.
+func HashFile(path, algorithm string) (string, error) {
+	h, err := NewHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyFile checks that the digest of a file matches the expected hex-encoded digest.
+// The comparison is case-insensitive.
+func VerifyFile(path, algorithm, expected string) error {
+	actual, err := HashFile(path, algorithm)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("%s mismatch for %s: expected %s, got %s", algorithm, path, expected, actual)
+	}
+
+	return nil
+}
+
+// Touch sets the modification time of a file to now. Installs touch the intact files they keep instead of
+// rewriting them, so that a snapshot of the directory taken before the install finds them as written by it.
+func Touch(path string) error {
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
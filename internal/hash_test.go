@@ -0,0 +1,55 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		algorithm string
+		want      string
+	}{
+		{"md5", "5d41402abc4b2a76b9719d911017c592"},
+		{"sha1", "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"},
+		{"sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			got, err := internal.HashFile(path, tc.algorithm)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected digest: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("verify mismatch", func(t *testing.T) {
+		if err := internal.VerifyFile(path, "sha1", "0000"); err == nil {
+			t.Error("expected error for mismatching digest, got nil")
+		}
+	})
+
+	t.Run("verify uppercase", func(t *testing.T) {
+		if err := internal.VerifyFile(path, "sha1", "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D"); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		if _, err := internal.HashFile(path, "crc32"); err == nil {
+			t.Error("expected error for unsupported algorithm, got nil")
+		}
+	})
+}
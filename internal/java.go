@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// FindJava returns the Java executable to use.
+// An explicit javaPath is returned as-is if it can be found; otherwise the executable is looked up
+// in $JAVA_HOME/bin and then in the PATH.
+func FindJava(javaPath string) (string, error) {
+	if javaPath != "" {
+		return exec.LookPath(javaPath)
+	}
+
+	executable := "java"
+	if runtime.GOOS == "windows" {
+		executable = "java.exe"
+	}
+
+	if javaHome := os.Getenv("JAVA_HOME"); javaHome != "" {
+		candidate := filepath.Join(javaHome, "bin", executable)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return "", errors.New("no Java executable found, install Java or set JAVA_HOME")
+	}
+	return path, nil
+}
+
+// javaVersionPattern matches the quoted version in the output of "java -version"
+// (e.g., `openjdk version "17.0.2" 2022-01-18` or `java version "1.8.0_292"`).
+var javaVersionPattern = regexp.MustCompile(`version "([^"]+)"`)
+
+// JavaMajorVersion runs "java -version" and returns the major version of the Java executable (e.g., 8, 17, 21).
+func JavaMajorVersion(ctx context.Context, javaPath string) (int, error) {
+	// "java -version" prints to stderr
+	output, err := exec.CommandContext(ctx, javaPath, "-version").CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run %s -version: %w", javaPath, err)
+	}
+	return ParseJavaVersion(string(output))
+}
+
+// ParseJavaVersion returns the Java major version from the output of "java -version".
+func ParseJavaVersion(output string) (int, error) {
+	match := javaVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("unrecognized java -version output: %q", output)
+	}
+	return JavaVersionMajor(match[1])
+}
+
+// JavaVersionMajor returns the major version of a Java version string (e.g., "17.0.2" -> 17, "1.8.0_292" -> 8, "8u51" -> 8).
+// Versions before Java 9 use the "1.x" scheme, so the second component is their major version.
+func JavaVersionMajor(version string) (int, error) {
+	// Split "1.8.0_292", "17.0.2" or "24-ea" into its numeric components
+	components := strings.FieldsFunc(version, func(r rune) bool { return r < '0' || r > '9' })
+	if len(components) == 0 {
+		return 0, fmt.Errorf("unrecognized Java version: %s", version)
+	}
+	major, err := strconv.Atoi(components[0])
+	if err != nil {
+		return 0, fmt.Errorf("unrecognized Java version: %s", version)
+	}
+	if major == 1 && len(components) > 1 {
+		// Legacy scheme: the major version is the second component
+		return strconv.Atoi(components[1])
+	}
+	return major, nil
+}
@@ -0,0 +1,86 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestFindJava(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake java executable is a shell script")
+	}
+
+	javaHome := t.TempDir()
+	javaPath := filepath.Join(javaHome, "bin", "java")
+	if err := os.MkdirAll(filepath.Dir(javaPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(javaPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("explicit path", func(t *testing.T) {
+		got, err := internal.FindJava(javaPath)
+		if err != nil || got != javaPath {
+			t.Errorf("expected %s, got %s (%v)", javaPath, got, err)
+		}
+	})
+
+	t.Run("JAVA_HOME", func(t *testing.T) {
+		t.Setenv("JAVA_HOME", javaHome)
+		got, err := internal.FindJava("")
+		if err != nil || got != javaPath {
+			t.Errorf("expected %s, got %s (%v)", javaPath, got, err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Setenv("JAVA_HOME", "")
+		t.Setenv("PATH", t.TempDir())
+		if _, err := internal.FindJava(""); err == nil {
+			t.Error("expected error when no java is available, got nil")
+		}
+	})
+
+	t.Run("explicit path missing", func(t *testing.T) {
+		if _, err := internal.FindJava(filepath.Join(javaHome, "missing")); err == nil {
+			t.Error("expected error for missing explicit path, got nil")
+		}
+	})
+}
+
+func TestParseJavaVersion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		output   string
+		expected int
+		wantErr  bool
+	}{
+		{"java 8", "java version \"1.8.0_292\"\nJava(TM) SE Runtime Environment (build 1.8.0_292-b10)\n", 8, false},
+		{"openjdk 17", "openjdk version \"17.0.2\" 2022-01-18\nOpenJDK Runtime Environment (build 17.0.2+8-86)\n", 17, false},
+		{"major only", "openjdk version \"21\" 2023-09-19\n", 21, false},
+		{"early access", "openjdk version \"24-ea\" 2025-03-18\n", 24, false},
+		{"unrecognized", "command not found\n", 0, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			major, err := internal.ParseJavaVersion(tc.output)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if major != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, major)
+			}
+		})
+	}
+}
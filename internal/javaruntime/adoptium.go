@@ -0,0 +1,235 @@
+package javaruntime
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// adoptiumAssets is the response of the Adoptium "assets/latest" endpoint.
+type adoptiumAssets []struct {
+	Binary struct {
+		Package struct {
+			Checksum string `json:"checksum"`
+			Link     string `json:"link"`
+			Name     string `json:"name"`
+		} `json:"package"`
+	} `json:"binary"`
+	ReleaseName string `json:"release_name"`
+}
+
+// adoptiumOS and adoptiumArch map GOOS and GOARCH to the names used by the Adoptium API.
+var (
+	adoptiumOS   = map[string]string{"linux": "linux", "darwin": "mac", "windows": "windows"}
+	adoptiumArch = map[string]string{"amd64": "x64", "386": "x32", "arm64": "aarch64", "arm": "arm", "ppc64le": "ppc64le", "s390x": "s390x"}
+)
+
+// installAdoptium installs the latest Temurin JRE of options.MajorVersion from the Adoptium API.
+func installAdoptium(ctx context.Context, dir string, options Options) (string, error) {
+	osName, ok := adoptiumOS[options.OS]
+	if !ok {
+		return "", fmt.Errorf("unsupported operating system for Adoptium: %s", options.OS)
+	}
+	archName, ok := adoptiumArch[options.Arch]
+	if !ok {
+		return "", fmt.Errorf("unsupported architecture for Adoptium: %s", options.Arch)
+	}
+
+	installed, err := prepareDir(dir, options.OS, options.MajorVersion)
+	if err != nil {
+		return "", err
+	}
+	if installed {
+		options.log("Java %d runtime is already installed", options.MajorVersion)
+		return findJava(dir, options.OS)
+	}
+
+	query := url.Values{
+		"architecture": {archName},
+		"image_type":   {"jre"},
+		"os":           {osName},
+		"vendor":       {"eclipse"},
+	}
+	assetsURL := fmt.Sprintf("%s/v3/assets/latest/%d/hotspot?%s", strings.TrimSuffix(options.AdoptiumURL, "/"), options.MajorVersion, query.Encode())
+
+	var assets adoptiumAssets
+	if err := internal.FetchJSON(ctx, assetsURL, &assets); err != nil {
+		return "", err
+	}
+	if len(assets) == 0 {
+		return "", fmt.Errorf("adoptium publishes no Java %d JRE for %s/%s", options.MajorVersion, options.OS, options.Arch)
+	}
+	asset := assets[0].Binary.Package
+
+	options.log("Installing Java %d runtime (%s)...", options.MajorVersion, assets[0].ReleaseName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	archivePath := filepath.Join(dir, asset.Name)
+	defer os.Remove(archivePath)
+
+	if err := internal.Download(ctx, asset.Link, archivePath, nil); err != nil {
+		return "", err
+	}
+	if err := internal.VerifyFile(archivePath, "sha256", asset.Checksum); err != nil {
+		return "", err
+	}
+
+	if strings.HasSuffix(asset.Name, ".zip") {
+		err = extractZipStripped(ctx, archivePath, dir)
+	} else {
+		err = extractTarGzStripped(ctx, archivePath, dir)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return findJava(dir, options.OS)
+}
+
+// stripFirst removes the top-level directory (e.g., "jdk-21.0.4+7-jre/") from an archive path.
+// It returns false for the top-level directory itself.
+func stripFirst(name string) (string, bool) {
+	_, rest, ok := strings.Cut(strings.TrimPrefix(name, "./"), "/")
+	if !ok || rest == "" {
+		return "", false
+	}
+	return rest, true
+}
+
+// extractTarGzStripped extracts a .tar.gz archive into dir without its top-level directory,
+// keeping the file modes (and therefore the executable bits) and symbolic links.
+func extractTarGzStripped(ctx context.Context, archivePath, dir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name, ok := stripFirst(header.Name)
+		if !ok {
+			continue
+		}
+		targetPath, err := internal.SafeJoin(dir, name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return err
+			}
+			os.Remove(targetPath)
+			if err := os.Symlink(header.Linkname, targetPath); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(targetPath, tarReader, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZipStripped extracts a .zip archive into dir without its top-level directory.
+func extractZipStripped(ctx context.Context, archivePath, dir string) error {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, entry := range zipReader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name, ok := stripFirst(entry.Name)
+		if !ok {
+			continue
+		}
+		targetPath, err := internal.SafeJoin(dir, name)
+		if err != nil {
+			return err
+		}
+
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		reader, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		mode := entry.Mode().Perm()
+		if mode == 0 {
+			mode = 0644
+		}
+		err = writeFile(targetPath, reader, mode)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes the content of reader to path with the given permissions, creating parent directories as needed.
+func writeFile(path string, reader io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	// OpenFile does not change the mode of an existing file
+	return os.Chmod(path, mode)
+}
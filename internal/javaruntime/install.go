@@ -0,0 +1,151 @@
+package javaruntime
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+const (
+	// SourceMojang installs the Java runtimes Mojang publishes for the Minecraft launcher.
+	SourceMojang = "mojang"
+
+	// SourceAdoptium installs an Eclipse Temurin JRE from an Adoptium-style API.
+	SourceAdoptium = "adoptium"
+
+	// MojangManifestURL lists the Java runtimes Mojang publishes for every platform.
+	MojangManifestURL = "https://piston-meta.mojang.com/v1/products/java-runtime/2ec0cc96c44e5a76b9c8b7c39df7210883d12871/all.json"
+
+	// AdoptiumURL is the base URL of the public Adoptium API.
+	AdoptiumURL = "https://api.adoptium.net"
+)
+
+// Options configures a Java runtime installation.
+type Options struct {
+	// Source selects where the runtime is downloaded from: SourceMojang (default) or SourceAdoptium.
+	Source string
+
+	// MajorVersion is the minimum Java major version the runtime must provide (e.g., 8, 17, 21).
+	MajorVersion int
+
+	// ManifestURL overrides MojangManifestURL.
+	ManifestURL string
+
+	// AdoptiumURL overrides the base URL of the Adoptium API (e.g., a mirror of api.adoptium.net).
+	AdoptiumURL string
+
+	// OS and Arch select the platform in GOOS/GOARCH notation. They default to the running platform.
+	OS, Arch string
+
+	// Log receives progress messages. It may be nil.
+	Log func(format string, v ...any)
+}
+
+// log prints a formatted message if a log function is configured.
+func (o Options) log(format string, v ...any) {
+	if o.Log != nil {
+		o.Log(format, v...)
+	}
+}
+
+// Install downloads a Java runtime into dir and returns the path of its Java executable.
+// Mojang runtimes are verified file by file with their SHA-1 hashes, Adoptium archives with their SHA-256 checksum.
+// A runtime of the same major version already present in dir is kept; one of another version is replaced.
+//
+// Parameters:
+//   - ctx: the context to control cancellation.
+//   - dir: the directory to install the runtime into (e.g., "<server>/runtime").
+//   - options: the installation options.
+//
+// Returns:
+//   - string: the path of the installed Java executable.
+//   - error: an error if no runtime is available for the platform and version, or if a download or hash check fails.
+func Install(ctx context.Context, dir string, options Options) (string, error) {
+	if options.OS == "" {
+		options.OS = runtime.GOOS
+	}
+	if options.Arch == "" {
+		options.Arch = runtime.GOARCH
+	}
+
+	switch options.Source {
+	case "", SourceMojang:
+		if options.ManifestURL == "" {
+			options.ManifestURL = MojangManifestURL
+		}
+		return installMojang(ctx, dir, options)
+	case SourceAdoptium:
+		if options.AdoptiumURL == "" {
+			options.AdoptiumURL = AdoptiumURL
+		}
+		return installAdoptium(ctx, dir, options)
+	default:
+		return "", fmt.Errorf("unknown Java runtime source: %s", options.Source)
+	}
+}
+
+// prepareDir removes a runtime of another major version from dir and reports whether a runtime of
+// the given major version is already installed, according to the "release" file of the Java home.
+// A runtime whose version cannot be determined is left in place to be overwritten.
+func prepareDir(dir, goos string, major int) (bool, error) {
+	javaPath, err := findJava(dir, goos)
+	if err != nil {
+		return false, nil
+	}
+
+	installed, err := releaseMajorVersion(filepath.Dir(filepath.Dir(javaPath)))
+	if err != nil {
+		return false, nil
+	}
+	if installed == major {
+		return true, nil
+	}
+	return false, os.RemoveAll(dir)
+}
+
+// releaseMajorVersion reads JAVA_VERSION from the "release" file of a Java home.
+func releaseMajorVersion(javaHome string) (int, error) {
+	file, err := os.Open(filepath.Join(javaHome, "release"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "JAVA_VERSION="); ok {
+			return internal.JavaVersionMajor(strings.Trim(value, `"`))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no JAVA_VERSION in %s", file.Name())
+}
+
+// findJava returns the Java executable of the runtime installed in dir.
+// macOS runtimes keep the Java home inside a bundle.
+func findJava(dir, goos string) (string, error) {
+	executable := "java"
+	if goos == "windows" {
+		executable = "java.exe"
+	}
+
+	candidates := []string{
+		filepath.Join(dir, "bin", executable),
+		filepath.Join(dir, "Contents", "Home", "bin", executable),
+		filepath.Join(dir, "jre.bundle", "Contents", "Home", "bin", executable),
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no Java executable found in %s", dir)
+}
@@ -0,0 +1,223 @@
+package javaruntime_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal/javaruntime"
+)
+
+func sha1Hex(data string) string {
+	sum := sha1.Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestInstallMojang(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the runtime uses unix file modes and links")
+	}
+
+	const javaContent = "#!/bin/sh\necho java\n"
+	const releaseContent = "JAVA_VERSION=\"17.0.8\"\n"
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/all.json":
+			fmt.Fprintf(w, `{"linux": {
+				"jre-legacy": [{"manifest": {"url": "%[1]s/legacy.json"}, "version": {"name": "8u51"}}],
+				"java-runtime-gamma": [{"manifest": {"url": "%[1]s/gamma.json"}, "version": {"name": "17.0.8"}}],
+				"java-runtime-delta": [{"manifest": {"url": "%[1]s/delta.json"}, "version": {"name": "21.0.3"}}],
+				"minecraft-java-exe": []
+			}}`, server.URL)
+		case "/gamma.json":
+			fmt.Fprintf(w, `{"files": {
+				"bin": {"type": "directory"},
+				"bin/java": {"type": "file", "executable": true, "downloads": {"raw": {"sha1": "%s", "url": "%s/files/java"}}},
+				"release": {"type": "file", "executable": false, "downloads": {"raw": {"sha1": "%s", "url": "%s/files/release"}}},
+				"lib/jexec": {"type": "link", "target": "../bin/java"}
+			}}`, sha1Hex(javaContent), server.URL, sha1Hex(releaseContent), server.URL)
+		case "/files/java":
+			fmt.Fprint(w, javaContent)
+		case "/files/release":
+			fmt.Fprint(w, releaseContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "runtime")
+	options := javaruntime.Options{
+		Source:       javaruntime.SourceMojang,
+		MajorVersion: 16,
+		ManifestURL:  server.URL + "/all.json",
+		OS:           "linux",
+		Arch:         "amd64",
+	}
+
+	javaPath, err := javaruntime.Install(context.Background(), dir, options)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if javaPath != filepath.Join(dir, "bin", "java") {
+		t.Errorf("unexpected Java path: %s", javaPath)
+	}
+
+	info, err := os.Stat(javaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("expected java to be executable, got mode %v", info.Mode())
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "lib", "jexec")); err != nil || target != "../bin/java" {
+		t.Errorf("unexpected link target %q (%v)", target, err)
+	}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		options := options
+		options.MajorVersion = 25
+		if _, err := javaruntime.Install(context.Background(), t.TempDir(), options); err == nil {
+			t.Error("expected error for an unavailable version, got nil")
+		}
+	})
+
+	t.Run("unsupported platform", func(t *testing.T) {
+		options := options
+		options.Arch = "arm64"
+		if _, err := javaruntime.Install(context.Background(), t.TempDir(), options); err == nil {
+			t.Error("expected error for an unavailable platform, got nil")
+		}
+	})
+}
+
+func TestInstallAdoptium(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the runtime uses unix file modes")
+	}
+
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	entries := []struct {
+		name    string
+		content string
+		mode    int64
+	}{
+		{"jdk-21.0.4+7-jre/bin/java", "#!/bin/sh\n", 0755},
+		{"jdk-21.0.4+7-jre/release", "JAVA_VERSION=\"21.0.4\"\n", 0644},
+	}
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: entry.mode, Size: int64(len(entry.content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+	archiveSum := sha256.Sum256(archive.Bytes())
+
+	downloads := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/assets/latest/21/hotspot":
+			if r.URL.Query().Get("os") != "linux" || r.URL.Query().Get("architecture") != "aarch64" || r.URL.Query().Get("image_type") != "jre" {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprintf(w, `[{"binary": {"package": {"checksum": "%s", "link": "%s/jre.tar.gz", "name": "OpenJDK21U-jre.tar.gz"}}, "release_name": "jdk-21.0.4+7"}]`,
+				hex.EncodeToString(archiveSum[:]), server.URL)
+		case "/jre.tar.gz":
+			downloads++
+			w.Write(archive.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "runtime")
+	options := javaruntime.Options{
+		Source:       javaruntime.SourceAdoptium,
+		MajorVersion: 21,
+		AdoptiumURL:  server.URL,
+		OS:           "linux",
+		Arch:         "arm64",
+	}
+
+	for i := 0; i < 2; i++ {
+		javaPath, err := javaruntime.Install(context.Background(), dir, options)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		info, err := os.Stat(javaPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if javaPath != filepath.Join(dir, "bin", "java") || info.Mode().Perm()&0100 == 0 {
+			t.Errorf("unexpected Java executable %s with mode %v", javaPath, info.Mode())
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("expected the installed runtime to be reused, got %d downloads", downloads)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "OpenJDK21U-jre.tar.gz")); !os.IsNotExist(err) {
+		t.Errorf("expected the archive to be removed, got: %v", err)
+	}
+}
+
+func TestUseInScript(t *testing.T) {
+	installDir := t.TempDir()
+
+	command, err := javaruntime.ScriptCommand(installDir, filepath.Join(installDir, "runtime", "bin", "java"), "linux")
+	if err != nil || command != "./runtime/bin/java" {
+		t.Fatalf("unexpected unix command %q (%v)", command, err)
+	}
+	command, err = javaruntime.ScriptCommand(installDir, filepath.Join(installDir, "runtime", "bin", "java.exe"), "windows")
+	if err != nil || command != `runtime\bin\java.exe` {
+		t.Fatalf("unexpected windows command %q (%v)", command, err)
+	}
+	if _, err := javaruntime.ScriptCommand(installDir, "/usr/bin/java", "linux"); err == nil {
+		t.Error("expected error for a runtime outside of the install directory, got nil")
+	}
+
+	scriptPath := filepath.Join(installDir, "run.sh")
+	script := "#!/usr/bin/env sh\n# Add custom JVM arguments to user_jvm_args.txt\njava @user_jvm_args.txt @libraries/unix_args.txt \"$@\"\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := javaruntime.UseInScript(scriptPath, "./runtime/bin/java")
+	if err != nil || !updated {
+		t.Fatalf("expected the script to be updated, got %v (%v)", updated, err)
+	}
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "#!/usr/bin/env sh\n# Add custom JVM arguments to user_jvm_args.txt\n./runtime/bin/java @user_jvm_args.txt @libraries/unix_args.txt \"$@\"\n"
+	if string(data) != expected {
+		t.Errorf("unexpected script:\n%s", data)
+	}
+
+	if updated, err := javaruntime.UseInScript(filepath.Join(installDir, "missing.sh"), "java"); err != nil || updated {
+		t.Errorf("expected a missing script to be skipped, got %v (%v)", updated, err)
+	}
+}
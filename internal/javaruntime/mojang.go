@@ -0,0 +1,190 @@
+package javaruntime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// mojangRuntimes maps a platform (e.g., "linux", "windows-x64") to its runtime components
+// (e.g., "java-runtime-delta", "jre-legacy").
+type mojangRuntimes map[string]map[string][]mojangRuntime
+
+// mojangRuntime is a release of a runtime component.
+type mojangRuntime struct {
+	Manifest struct {
+		URL string `json:"url"`
+	} `json:"manifest"`
+	Version struct {
+		Name string `json:"name"`
+	} `json:"version"`
+}
+
+// mojangFileManifest lists the files of a runtime component.
+type mojangFileManifest struct {
+	Files map[string]struct {
+		Type       string `json:"type"`
+		Executable bool   `json:"executable"`
+		Target     string `json:"target"`
+		Downloads  struct {
+			Raw *struct {
+				SHA1 string `json:"sha1"`
+				URL  string `json:"url"`
+			} `json:"raw"`
+		} `json:"downloads"`
+	} `json:"files"`
+}
+
+// mojangComponent is a runtime component available for the platform.
+type mojangComponent struct {
+	name        string
+	major       int
+	manifestURL string
+}
+
+// mojangPlatforms maps GOOS/GOARCH to the platform names of the Mojang runtime manifest.
+var mojangPlatforms = map[string]string{
+	"linux/amd64":   "linux",
+	"linux/386":     "linux-i386",
+	"darwin/amd64":  "mac-os",
+	"darwin/arm64":  "mac-os-arm64",
+	"windows/amd64": "windows-x64",
+	"windows/386":   "windows-x86",
+	"windows/arm64": "windows-arm64",
+}
+
+// installMojang installs the Mojang runtime component with the lowest major version that satisfies options.MajorVersion.
+func installMojang(ctx context.Context, dir string, options Options) (string, error) {
+	platform, ok := mojangPlatforms[options.OS+"/"+options.Arch]
+	if !ok {
+		return "", fmt.Errorf("mojang publishes no Java runtime for %s/%s, use the Adoptium source instead", options.OS, options.Arch)
+	}
+
+	var runtimes mojangRuntimes
+	if err := internal.FetchJSON(ctx, options.ManifestURL, &runtimes); err != nil {
+		return "", err
+	}
+
+	component, err := selectMojangComponent(runtimes[platform], options.MajorVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := prepareDir(dir, options.OS, component.major); err != nil {
+		return "", err
+	}
+
+	options.log("Installing Java %d runtime (%s)...", component.major, component.name)
+	var files mojangFileManifest
+	if err := internal.FetchJSON(ctx, component.manifestURL, &files); err != nil {
+		return "", err
+	}
+	if err := installMojangFiles(ctx, dir, files, options.OS); err != nil {
+		return "", err
+	}
+
+	return findJava(dir, options.OS)
+}
+
+// selectMojangComponent selects the component with the lowest major version that is at least major.
+func selectMojangComponent(components map[string][]mojangRuntime, major int) (mojangComponent, error) {
+	var candidates []mojangComponent
+	for name, entries := range components {
+		if len(entries) == 0 {
+			continue
+		}
+		entryMajor, err := internal.JavaVersionMajor(entries[0].Version.Name)
+		if err != nil || entryMajor < major {
+			continue
+		}
+		candidates = append(candidates, mojangComponent{
+			name:        name,
+			major:       entryMajor,
+			manifestURL: entries[0].Manifest.URL,
+		})
+	}
+	if len(candidates) == 0 {
+		return mojangComponent{}, fmt.Errorf("no Mojang Java runtime provides Java %d for this platform", major)
+	}
+
+	// Prefer the lowest major version; among equal ones the name decides, so that the choice is stable
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].major != candidates[j].major {
+			return candidates[i].major < candidates[j].major
+		}
+		return candidates[i].name > candidates[j].name
+	})
+	return candidates[0], nil
+}
+
+// installMojangFiles creates the directories, files and links of a runtime manifest in dir.
+// Files that already match their hash are not downloaded again.
+func installMojangFiles(ctx context.Context, dir string, files mojangFileManifest, goos string) error {
+	// Sort the paths so that directories are created before their content
+	names := make([]string, 0, len(files.Files))
+	for name := range files.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		file := files.Files[name]
+		targetPath, err := internal.SafeJoin(dir, name)
+		if err != nil {
+			return err
+		}
+
+		switch file.Type {
+		case "directory":
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+		case "link":
+			// Windows runtimes have no links, and creating them there needs extra privileges
+			if goos == "windows" {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return err
+			}
+			os.Remove(targetPath)
+			if err := os.Symlink(file.Target, targetPath); err != nil {
+				return err
+			}
+		case "file":
+			if file.Downloads.Raw == nil {
+				return fmt.Errorf("no download for Java runtime file %s", name)
+			}
+			if internal.VerifyFile(targetPath, "sha1", file.Downloads.Raw.SHA1) != nil {
+				if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+					return err
+				}
+				if err := internal.Download(ctx, file.Downloads.Raw.URL, targetPath, nil); err != nil {
+					return fmt.Errorf("failed to download %s: %w", name, err)
+				}
+				if err := internal.VerifyFile(targetPath, "sha1", file.Downloads.Raw.SHA1); err != nil {
+					os.Remove(targetPath)
+					return err
+				}
+			}
+
+			mode := os.FileMode(0644)
+			if file.Executable {
+				mode = 0755
+			}
+			if err := os.Chmod(targetPath, mode); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package javaruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScriptCommand returns the command that runs javaPath from a start script in installDir.
+// The path is relative to installDir, so that the server directory can be moved as a whole.
+func ScriptCommand(installDir, javaPath, goos string) (string, error) {
+	relative, err := filepath.Rel(installDir, javaPath)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(relative, "..") {
+		return "", fmt.Errorf("java runtime %s is outside of %s", javaPath, installDir)
+	}
+
+	if goos == "windows" {
+		command := strings.ReplaceAll(relative, "/", `\`)
+		if strings.Contains(command, " ") {
+			command = `"` + command + `"`
+		}
+		return command, nil
+	}
+
+	command := "./" + filepath.ToSlash(relative)
+	if strings.Contains(command, " ") {
+		command = `"` + command + `"`
+	}
+	return command, nil
+}
+
+// UseInScript replaces the "java" command at the start of the lines of a start script (e.g., Forge's run.sh) with command.
+// It reports whether the script exists and was updated.
+func UseInScript(scriptPath, command string) (bool, error) {
+	data, err := os.ReadFile(scriptPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "java "); ok {
+			lines[i] = command + " " + rest
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	return true, os.WriteFile(scriptPath, []byte(strings.Join(lines, "\n")), 0755)
+}
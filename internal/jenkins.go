@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// JenkinsArtifact describes a single file archived by a Jenkins build.
+type JenkinsArtifact struct {
+	FileName     string `json:"fileName"`
+	RelativePath string `json:"relativePath"`
+}
+
+// JenkinsBuild describes a single build of a Jenkins job as returned by the JSON API.
+type JenkinsBuild struct {
+	Number    int               `json:"number"`
+	Result    string            `json:"result"`
+	Artifacts []JenkinsArtifact `json:"artifacts"`
+}
+
+// FetchJenkinsBuilds fetches the builds of a Jenkins job through its JSON API with context support.
+// Only the fields required to locate artifacts are requested from the server.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - jobURL: the URL of the Jenkins job (e.g., "https://ci.pufferfish.host/job/Pufferfish-1.21").
+//
+// Returns:
+//   - []JenkinsBuild: the builds of the job, newest first.
+//   - error: an error if the HTTP request fails or the JSON cannot be decoded.
+func FetchJenkinsBuilds(ctx context.Context, jobURL string) ([]JenkinsBuild, error) {
+	url := strings.TrimSuffix(jobURL, "/") + "/api/json?tree=builds[number,result,artifacts[fileName,relativePath]]"
+
+	var jobData struct {
+		Builds []JenkinsBuild `json:"builds"`
+	}
+	if err := FetchJSON(ctx, url, &jobData); err != nil {
+		return nil, err
+	}
+
+	return jobData.Builds, nil
+}
+
+// JenkinsArtifactURL returns the download URL of an artifact archived by a Jenkins build.
+func JenkinsArtifactURL(jobURL string, buildNumber int, artifact JenkinsArtifact) string {
+	return fmt.Sprintf("%s/%d/artifact/%s", strings.TrimSuffix(jobURL, "/"), buildNumber, artifact.RelativePath)
+}
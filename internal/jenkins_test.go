@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestFetchJenkinsBuilds(t *testing.T) {
+	const jobJSON = `{"builds":[{"number":12,"result":"SUCCESS","artifacts":[{"fileName":"server-1.21.4.jar","relativePath":"build/libs/server-1.21.4.jar"}]},{"number":11,"result":"FAILURE","artifacts":[]}]}`
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/Server/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, jobJSON)
+	}))
+	defer testServer.Close()
+
+	t.Run("success", func(t *testing.T) {
+		builds, err := internal.FetchJenkinsBuilds(context.Background(), testServer.URL+"/job/Server/")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(builds) != 2 {
+			t.Fatalf("expected 2 builds, got %d", len(builds))
+		}
+		if builds[0].Number != 12 || builds[0].Result != "SUCCESS" || len(builds[0].Artifacts) != 1 {
+			t.Errorf("unexpected first build: %+v", builds[0])
+		}
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		if _, err := internal.FetchJenkinsBuilds(context.Background(), testServer.URL+"/job/Missing"); err == nil {
+			t.Error("expected error for missing job, got nil")
+		}
+	})
+}
+
+func TestJenkinsArtifactURL(t *testing.T) {
+	artifact := internal.JenkinsArtifact{FileName: "server.jar", RelativePath: "build/libs/server.jar"}
+
+	got := internal.JenkinsArtifactURL("https://ci.example.com/job/Server/", 7, artifact)
+	want := "https://ci.example.com/job/Server/7/artifact/build/libs/server.jar"
+	if got != want {
+		t.Errorf("unexpected artifact URL: got %q, want %q", got, want)
+	}
+}
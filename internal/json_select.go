@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonMember is a single key-value pair of a JSON object.
+// Objects are decoded into ordered members so that selectors preserve the order of the input.
+type jsonMember struct {
+	key   string
+	value any
+}
+
+// selectorStep is one step of a parsed JSON selector.
+type selectorStep struct {
+	key      string // object key to descend into
+	index    int    // array index to descend into
+	isIndex  bool   // whether the step selects an array index
+	wildcard bool   // whether the step selects every element or member value
+}
+
+// SelectJSON evaluates a JSONPath-like selector against a JSON document and returns the selected scalar values as strings.
+//
+// The supported selector syntax is a subset of JSONPath:
+//   - "$" refers to the document root and is optional.
+//   - ".key" or "['key']" selects an object member.
+//   - "[0]" selects an array element; negative indexes count from the end.
+//   - "[*]" or ".*" selects every array element or object member value, in document order.
+//
+// Example: "$.versions.*[*]" selects every element of every array stored in the "versions" object.
+//
+// Parameters:
+//   - data: the JSON document.
+//   - selector: the selector to evaluate.
+//
+// Returns:
+//   - []string: the selected strings, numbers and booleans, in document order. Missing members are skipped.
+//   - error: an error if the document or selector is invalid, or if a selected value is an object, array or null.
+func SelectJSON(data []byte, selector string) ([]string, error) {
+	steps, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	root, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	current := []any{root}
+	for _, step := range steps {
+		next := make([]any, 0, len(current))
+		for _, value := range current {
+			next = append(next, applySelectorStep(value, step)...)
+		}
+		current = next
+	}
+
+	results := make([]string, 0, len(current))
+	for _, value := range current {
+		switch v := value.(type) {
+		case string:
+			results = append(results, v)
+		case json.Number:
+			results = append(results, v.String())
+		case bool:
+			results = append(results, strconv.FormatBool(v))
+		default:
+			return nil, fmt.Errorf("selector %q matched a non-scalar value", selector)
+		}
+	}
+
+	return results, nil
+}
+
+// parseSelector splits a selector into its steps.
+func parseSelector(selector string) ([]selectorStep, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(selector), "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		// Allow selectors without the leading root, such as "versions[*]"
+		rest = "." + rest
+	}
+
+	var steps []selectorStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+
+			if name == "" {
+				return nil, fmt.Errorf("invalid selector %q: empty member name", selector)
+			}
+			if name == "*" {
+				steps = append(steps, selectorStep{wildcard: true})
+			} else {
+				steps = append(steps, selectorStep{key: name})
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid selector %q: missing ']'", selector)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			switch {
+			case inner == "*":
+				steps = append(steps, selectorStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, selectorStep{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid selector %q: invalid index %q", selector, inner)
+				}
+				steps = append(steps, selectorStep{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("invalid selector %q: unexpected character %q", selector, rest[0])
+		}
+	}
+
+	return steps, nil
+}
+
+// applySelectorStep applies a single selector step to a decoded value.
+func applySelectorStep(value any, step selectorStep) []any {
+	switch v := value.(type) {
+	case []jsonMember:
+		if step.wildcard {
+			values := make([]any, 0, len(v))
+			for _, member := range v {
+				values = append(values, member.value)
+			}
+			return values
+		}
+		if step.isIndex {
+			return nil
+		}
+		for _, member := range v {
+			if member.key == step.key {
+				return []any{member.value}
+			}
+		}
+	case []any:
+		if step.wildcard {
+			return v
+		}
+		if !step.isIndex {
+			return nil
+		}
+		index := step.index
+		if index < 0 {
+			index += len(v)
+		}
+		if index >= 0 && index < len(v) {
+			return []any{v[index]}
+		}
+	}
+
+	return nil
+}
+
+// decodeOrdered decodes the next JSON value, keeping object members in document order.
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		members := make([]jsonMember, 0)
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string key, but got a token of type %T", keyToken)
+			}
+
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, jsonMember{key: key, value: value})
+		}
+		// Read the closing '}'
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return members, nil
+	case '[':
+		elements := make([]any, 0)
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, value)
+		}
+		// Read the closing ']'
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return elements, nil
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %q", delim)
+	}
+}
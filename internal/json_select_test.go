@@ -0,0 +1,64 @@
+package internal_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestSelectJSON(t *testing.T) {
+	const document = `{
+		"project": "paper",
+		"versions": {"1.21": ["1.21.1", "1.21"], "1.20": ["1.20.6"]},
+		"builds": [101, 102, 103],
+		"downloads": {"server:default": {"url": "https://example.com/server.jar"}},
+		"stable": true
+	}`
+
+	testCases := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{"member", "$.project", []string{"paper"}},
+		{"optional root", "project", []string{"paper"}},
+		{"object values in order", "$.versions.*[*]", []string{"1.21.1", "1.21", "1.20.6"}},
+		{"numbers", "$.builds[*]", []string{"101", "102", "103"}},
+		{"index", "$.builds[0]", []string{"101"}},
+		{"negative index", "$.builds[-1]", []string{"103"}},
+		{"quoted key", "$.downloads['server:default'].url", []string{"https://example.com/server.jar"}},
+		{"boolean", "$.stable", []string{"true"}},
+		{"missing member", "$.missing", []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := internal.SelectJSON([]byte(document), tc.selector)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("unexpected result: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("non-scalar value", func(t *testing.T) {
+		if _, err := internal.SelectJSON([]byte(document), "$.versions"); err == nil {
+			t.Error("expected error for object result, got nil")
+		}
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		if _, err := internal.SelectJSON([]byte(document), "$.builds[x]"); err == nil {
+			t.Error("expected error for invalid index, got nil")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		if _, err := internal.SelectJSON([]byte("not a json"), "$"); err == nil {
+			t.Error("expected error for invalid JSON, got nil")
+		}
+	})
+}
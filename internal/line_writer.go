@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// LineWriter implements io.Writer and passes every complete line written to it to Log.
+// It is intended to stream the output of external processes into a logger.
+type LineWriter struct {
+	// Log receives each line without its trailing newline. It may be nil.
+	Log func(format string, v ...any)
+
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write implements the io.Writer interface.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buffer.Write(p)
+	for {
+		line, err := w.buffer.ReadString('\n')
+		if err != nil {
+			// Keep the incomplete line for the next write
+			w.buffer.Reset()
+			w.buffer.WriteString(line)
+			break
+		}
+		w.emit(line)
+	}
+
+	return len(p), nil
+}
+
+// Flush logs any remaining incomplete line.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buffer.Len() > 0 {
+		w.emit(w.buffer.String())
+		w.buffer.Reset()
+	}
+}
+
+// emit logs a single line. The caller must hold mu.
+func (w *LineWriter) emit(line string) {
+	line = strings.TrimRight(line, "\r\n")
+	if w.Log != nil && line != "" {
+		w.Log("%s", line)
+	}
+}
@@ -0,0 +1,25 @@
+package internal_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	writer := &internal.LineWriter{Log: func(format string, v ...any) {
+		lines = append(lines, fmt.Sprintf(format, v...))
+	}}
+
+	fmt.Fprint(writer, "first line\nsecond ")
+	fmt.Fprint(writer, "line\r\n\nthird")
+	writer.Flush()
+
+	want := []string{"first line", "second line", "third"}
+	if !slices.Equal(lines, want) {
+		t.Errorf("unexpected lines: got %q, want %q", lines, want)
+	}
+}
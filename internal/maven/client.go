@@ -0,0 +1,248 @@
+package maven
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// Well-known repositories.
+const (
+	Central            = "https://repo.maven.apache.org/maven2"
+	MinecraftForge     = "https://maven.minecraftforge.net"
+	NeoForged          = "https://maven.neoforged.net/releases"
+	MinecraftLibraries = "https://libraries.minecraft.net"
+)
+
+// ErrNotFound is returned when no repository contains the requested file.
+var ErrNotFound = errors.New("not found in any maven repository")
+
+// Metadata is the content of a "maven-metadata.xml" file.
+type Metadata struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Versioning struct {
+		Latest   string   `xml:"latest"`
+		Release  string   `xml:"release"`
+		Versions []string `xml:"versions>version"`
+	} `xml:"versioning"`
+}
+
+// Client resolves artifacts against a list of repositories.
+// Repositories are tried in order; the first one that has the requested file is used.
+type Client struct {
+	Repositories []string
+}
+
+// NewClient creates a client for the given repository base URLs.
+func NewClient(repositories ...string) *Client {
+	return &Client{Repositories: repositories}
+}
+
+// Metadata fetches and decodes the "maven-metadata.xml" of an artifact with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - group: the group ID (e.g., "net.neoforged").
+//   - artifact: the artifact ID (e.g., "neoforge").
+//
+// Returns:
+//   - *Metadata: the metadata from the first repository that has it. Versions are in the repository's order (usually oldest first).
+//   - error: an error wrapping ErrNotFound if no repository has the metadata, or the last request or XML decoding error.
+func (c *Client) Metadata(ctx context.Context, group, artifact string) (*Metadata, error) {
+	relativePath := groupPath(group) + "/" + artifact + "/maven-metadata.xml"
+
+	var errs []error
+	for _, repository := range c.Repositories {
+		url := strings.TrimSuffix(repository, "/") + "/" + relativePath
+
+		data, err := fetch(ctx, url)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var metadata Metadata
+		if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&metadata); err != nil {
+			errs = append(errs, fmt.Errorf("failed to decode XML from %s: %w", url, err))
+			continue
+		}
+		return &metadata, nil
+	}
+
+	return nil, resolveError(group+":"+artifact, errs)
+}
+
+// ResolveURL returns the URL of an artifact in the first repository that has it.
+// With a single repository the URL is returned without a request.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - coordinate: the artifact to resolve.
+//
+// Returns:
+//   - string: the artifact URL.
+//   - error: an error wrapping ErrNotFound if no repository has the artifact.
+func (c *Client) ResolveURL(ctx context.Context, coordinate Coordinate) (string, error) {
+	if len(c.Repositories) == 1 {
+		return coordinate.URL(c.Repositories[0]), nil
+	}
+
+	var errs []error
+	for _, repository := range c.Repositories {
+		url := coordinate.URL(repository)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request for %s: %w", url, err)
+		}
+		response, err := http.DefaultClient.Do(req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch %s: %w", url, err))
+			continue
+		}
+		response.Body.Close()
+
+		if response.StatusCode == http.StatusOK {
+			return url, nil
+		}
+		errs = append(errs, statusError(url, response.StatusCode))
+	}
+
+	return "", resolveError(coordinate.String(), errs)
+}
+
+// Download downloads an artifact to path from the first repository that has it,
+// and verifies it against the repository's ".sha256" or ".sha1" sidecar file when one is published.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - coordinate: the artifact to download.
+//   - path: the destination file path.
+//   - onProgress: an optional download progress callback.
+//
+// Returns:
+//   - error: an error if no repository provides the artifact, or if the checksum verification fails.
+//     A file that fails verification is removed.
+func (c *Client) Download(ctx context.Context, coordinate Coordinate, path string, onProgress func(current, total int64)) error {
+	var errs []error
+	for _, repository := range c.Repositories {
+		url := coordinate.URL(repository)
+
+		if err := internal.Download(ctx, url, path, onProgress); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("failed to download %s: %w", url, err))
+			continue
+		}
+
+		if err := VerifyChecksum(ctx, url, path); err != nil {
+			os.Remove(path)
+			return err
+		}
+		return nil
+	}
+
+	return resolveError(coordinate.String(), errs)
+}
+
+// VerifyChecksum verifies a downloaded file against the ".sha256" or ".sha1" sidecar file published next to its URL.
+// Files without a published checksum are accepted.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - url: the URL the file was downloaded from.
+//   - path: the downloaded file.
+//
+// Returns:
+//   - error: an error if a checksum is published and does not match, or if a sidecar cannot be fetched.
+func VerifyChecksum(ctx context.Context, url, path string) error {
+	algorithm, digest, err := FetchChecksum(ctx, url)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return internal.VerifyFile(path, algorithm, digest)
+}
+
+// FetchChecksum fetches the ".sha256" or ".sha1" sidecar file published next to a URL, preferring SHA-256.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - url: the URL of the file.
+//
+// Returns:
+//   - string: the hash algorithm of the sidecar ("sha256" or "sha1").
+//   - string: the lowercase hex-encoded digest.
+//   - error: ErrNotFound if no checksum is published, or an error if a sidecar cannot be fetched.
+func FetchChecksum(ctx context.Context, url string) (string, string, error) {
+	for _, algorithm := range []string{"sha256", "sha1"} {
+		data, err := fetch(ctx, url+"."+algorithm)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		// Sidecars contain the hex digest, optionally followed by the file name
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 {
+			continue
+		}
+		return algorithm, strings.ToLower(fields[0]), nil
+	}
+
+	return "", "", fmt.Errorf("checksum of %s: %w", url, ErrNotFound)
+}
+
+// fetch fetches the body of a URL. A 404 response is reported as ErrNotFound.
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, statusError(url, response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// statusError describes an unsuccessful HTTP response. A 404 response wraps ErrNotFound.
+func statusError(url string, statusCode int) error {
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", url, ErrNotFound)
+	}
+	return fmt.Errorf("unexpected status %d when fetching %s", statusCode, url)
+}
+
+// resolveError combines the errors of all repositories that were tried.
+func resolveError(name string, errs []error) error {
+	if len(errs) == 0 {
+		return fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return fmt.Errorf("failed to resolve %s: %w", name, errors.Join(errs...))
+}
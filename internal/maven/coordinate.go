@@ -0,0 +1,93 @@
+package maven
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Coordinate identifies a single artifact file in a Maven repository.
+type Coordinate struct {
+	Group      string // e.g., "net.minecraftforge"
+	Artifact   string // e.g., "forge"
+	Version    string // e.g., "1.20.1-47.2.0"
+	Classifier string // e.g., "installer"; empty for the main artifact
+	Extension  string // e.g., "jar"; empty means "jar"
+}
+
+// ParseCoordinate parses a Maven coordinate in the form "group:artifact:version[:classifier][@extension]".
+//
+// Parameters:
+//   - coordinate: the coordinate string (e.g., "net.minecraftforge:forge:1.20.1-47.2.0:installer@jar").
+//
+// Returns:
+//   - Coordinate: the parsed coordinate.
+//   - error: an error if the coordinate does not have three or four non-empty parts.
+func ParseCoordinate(coordinate string) (Coordinate, error) {
+	var c Coordinate
+
+	rest := coordinate
+	if at := strings.LastIndex(rest, "@"); at != -1 {
+		c.Extension = rest[at+1:]
+		rest = rest[:at]
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Coordinate{}, fmt.Errorf("invalid maven coordinate: %s", coordinate)
+	}
+
+	c.Group, c.Artifact, c.Version = parts[0], parts[1], parts[2]
+	if len(parts) == 4 {
+		c.Classifier = parts[3]
+	}
+
+	return c, nil
+}
+
+// String returns the coordinate in the form "group:artifact:version[:classifier][@extension]".
+func (c Coordinate) String() string {
+	s := c.Group + ":" + c.Artifact + ":" + c.Version
+	if c.Classifier != "" {
+		s += ":" + c.Classifier
+	}
+	if c.Extension != "" && c.Extension != "jar" {
+		s += "@" + c.Extension
+	}
+	return s
+}
+
+// FileName returns the file name of the artifact (e.g., "forge-1.20.1-47.2.0-installer.jar").
+func (c Coordinate) FileName() string {
+	extension := c.Extension
+	if extension == "" {
+		extension = "jar"
+	}
+
+	name := c.Artifact + "-" + c.Version
+	if c.Classifier != "" {
+		name += "-" + c.Classifier
+	}
+	return name + "." + extension
+}
+
+// Path returns the relative repository path of the artifact
+// (e.g., "net/minecraftforge/forge/1.20.1-47.2.0/forge-1.20.1-47.2.0-installer.jar").
+func (c Coordinate) Path() string {
+	return strings.Join([]string{groupPath(c.Group), c.Artifact, c.Version, c.FileName()}, "/")
+}
+
+// LocalPath returns the path of the artifact inside a local repository directory (e.g., a "libraries" directory).
+func (c Coordinate) LocalPath(baseDir string) string {
+	return filepath.Join(baseDir, filepath.FromSlash(c.Path()))
+}
+
+// URL returns the URL of the artifact in the given repository.
+func (c Coordinate) URL(repository string) string {
+	return strings.TrimSuffix(repository, "/") + "/" + c.Path()
+}
+
+// groupPath converts a group ID into its repository directory (e.g., "net.minecraftforge" -> "net/minecraftforge").
+func groupPath(group string) string {
+	return strings.ReplaceAll(group, ".", "/")
+}
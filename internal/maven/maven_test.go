@@ -0,0 +1,145 @@
+package maven_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	testCases := []struct {
+		coordinate string
+		path       string
+		wantErr    bool
+	}{
+		{"net.neoforged:neoforge:21.0.142-beta", "net/neoforged/neoforge/21.0.142-beta/neoforge-21.0.142-beta.jar", false},
+		{"net.minecraftforge:forge:1.20.1-47.2.0:installer", "net/minecraftforge/forge/1.20.1-47.2.0/forge-1.20.1-47.2.0-installer.jar", false},
+		{"net.minecraftforge:forge:1.5.2-7.8.1.738:universal@zip", "net/minecraftforge/forge/1.5.2-7.8.1.738/forge-1.5.2-7.8.1.738-universal.zip", false},
+		{"de.oceanlabs.mcp:mcp_config:1.20.1-20230612.114412@zip", "de/oceanlabs/mcp/mcp_config/1.20.1-20230612.114412/mcp_config-1.20.1-20230612.114412.zip", false},
+		{"net.minecraftforge:forge", "", true},
+		{"a:b:c:d:e", "", true},
+		{"a::c", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.coordinate, func(t *testing.T) {
+			coordinate, err := maven.ParseCoordinate(tc.coordinate)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got := coordinate.Path(); got != tc.path {
+				t.Errorf("expected path %s, got %s", tc.path, got)
+			}
+			if got := coordinate.String(); got != tc.coordinate {
+				t.Errorf("expected String() %s, got %s", tc.coordinate, got)
+			}
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	const artifactContent = "artifact"
+	sum := sha1.Sum([]byte(artifactContent))
+	artifactSHA1 := hex.EncodeToString(sum[:])
+
+	// The first repository has nothing, the second one has the artifact and its metadata
+	emptyRepository := httptest.NewServer(http.NotFoundHandler())
+	defer emptyRepository.Close()
+
+	repository := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/example/lib/maven-metadata.xml":
+			fmt.Fprint(w, `<metadata><groupId>org.example</groupId><artifactId>lib</artifactId><versioning><latest>1.1</latest><release>1.1</release><versions><version>1.0</version><version>1.1</version></versions></versioning></metadata>`)
+		case "/org/example/lib/1.0/lib-1.0.jar", "/org/example/lib/1.1/lib-1.1.jar":
+			fmt.Fprint(w, artifactContent)
+		case "/org/example/lib/1.0/lib-1.0.jar.sha1":
+			fmt.Fprintf(w, "%s  lib-1.0.jar\n", artifactSHA1)
+		case "/org/example/lib/1.1/lib-1.1.jar.sha1":
+			fmt.Fprint(w, "0000000000000000000000000000000000000000")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer repository.Close()
+
+	client := maven.NewClient(emptyRepository.URL, repository.URL)
+	ctx := context.Background()
+
+	t.Run("metadata", func(t *testing.T) {
+		metadata, err := client.Metadata(ctx, "org.example", "lib")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if metadata.Versioning.Release != "1.1" || !slices.Equal(metadata.Versioning.Versions, []string{"1.0", "1.1"}) {
+			t.Errorf("unexpected metadata: %+v", metadata.Versioning)
+		}
+	})
+
+	t.Run("metadata not found", func(t *testing.T) {
+		_, err := client.Metadata(ctx, "org.example", "missing")
+		if !errors.Is(err, maven.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("resolve url", func(t *testing.T) {
+		url, err := client.ResolveURL(ctx, maven.Coordinate{Group: "org.example", Artifact: "lib", Version: "1.0"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if want := repository.URL + "/org/example/lib/1.0/lib-1.0.jar"; url != want {
+			t.Errorf("expected %s, got %s", want, url)
+		}
+	})
+
+	t.Run("download verified", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "lib.jar")
+		if err := client.Download(ctx, maven.Coordinate{Group: "org.example", Artifact: "lib", Version: "1.0"}, path, nil); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != artifactContent {
+			t.Errorf("unexpected downloaded content %q (%v)", content, err)
+		}
+	})
+
+	t.Run("fetch checksum", func(t *testing.T) {
+		algorithm, digest, err := maven.FetchChecksum(ctx, repository.URL+"/org/example/lib/1.0/lib-1.0.jar")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if algorithm != "sha1" || digest != artifactSHA1 {
+			t.Errorf("unexpected checksum %s %s", algorithm, digest)
+		}
+
+		if _, _, err := maven.FetchChecksum(ctx, repository.URL+"/org/example/lib/maven-metadata.xml"); !errors.Is(err, maven.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got: %v", err)
+		}
+	})
+
+	t.Run("download checksum mismatch", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "lib.jar")
+		if err := client.Download(ctx, maven.Coordinate{Group: "org.example", Artifact: "lib", Version: "1.1"}, path, nil); err == nil {
+			t.Fatal("expected error for checksum mismatch, got nil")
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Error("file with mismatching checksum should have been removed")
+		}
+	})
+}
@@ -2,16 +2,37 @@ package internal
 
 import (
 	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"strings"
 )
 
+// MergeOptions controls which entries MergeZips writes and how the manifest is written.
+type MergeOptions struct {
+	// Filter reports whether an entry of either archive is kept. A nil Filter keeps every entry.
+	Filter func(name string) bool
+
+	// RewriteManifest rewrites the content of "META-INF/MANIFEST.MF". A nil RewriteManifest keeps it unchanged.
+	RewriteManifest func(manifest []byte) []byte
+}
+
+// StripSignatures is a MergeOptions value that removes jar signatures from the merged archive:
+// the signature files are dropped and the per-entry digests are removed from the manifest.
+// A modified jar that keeps its stale signatures fails verification with a SecurityException.
+var StripSignatures = MergeOptions{
+	Filter:          func(name string) bool { return !IsJarSignatureFile(name) },
+	RewriteManifest: StripManifestDigests,
+}
+
 // MergeZips combines two zip archives. It overlays the files from overlayZipPath
 // on top of baseZipPath. If a file exists in both archives, the one from
 // overlayZipPath is used in the final outputZipPath.
-func MergeZips(ctx context.Context, baseZipPath, overlayZipPath, outputZipPath string) (err error) {
+// Entries are filtered and the manifest is rewritten according to options.
+func MergeZips(ctx context.Context, baseZipPath, overlayZipPath, outputZipPath string, options MergeOptions) (err error) {
 	// Check if context is already cancelled
 	if err := ctx.Err(); err != nil {
 		return err
@@ -54,8 +75,12 @@ func MergeZips(ctx context.Context, baseZipPath, overlayZipPath, outputZipPath s
 		// Record the file name to track that it has been added
 		overlayFiles[file.Name] = struct{}{}
 
+		if options.Filter != nil && !options.Filter(file.Name) {
+			continue
+		}
+
 		// Copy the file from the overlay zip to the new zip
-		if err := copyFileToZip(zipWriter, file); err != nil {
+		if err := copyFileToZip(zipWriter, file, options); err != nil {
 			return fmt.Errorf("failed to copy file '%s' from overlay zip: %w", file.Name, err)
 		}
 	}
@@ -73,8 +98,12 @@ func MergeZips(ctx context.Context, baseZipPath, overlayZipPath, outputZipPath s
 			return err
 		}
 
+		if options.Filter != nil && !options.Filter(file.Name) {
+			continue
+		}
+
 		if _, exists := overlayFiles[file.Name]; !exists {
-			if err := copyFileToZip(zipWriter, file); err != nil {
+			if err := copyFileToZip(zipWriter, file, options); err != nil {
 				return fmt.Errorf("failed to copy file '%s' from base zip: %w", file.Name, err)
 			}
 		}
@@ -94,8 +123,8 @@ func MergeZips(ctx context.Context, baseZipPath, overlayZipPath, outputZipPath s
 }
 
 // copyFileToZip is a helper function that copies a single file from a source
-// zip archive to a destination zip writer
-func copyFileToZip(writer *zip.Writer, file *zip.File) error {
+// zip archive to a destination zip writer, rewriting the manifest if requested
+func copyFileToZip(writer *zip.Writer, file *zip.File, options MergeOptions) error {
 	// Open the file inside the zip
 	srcFile, err := file.Open()
 	if err != nil {
@@ -111,7 +140,90 @@ func copyFileToZip(writer *zip.Writer, file *zip.File) error {
 		return err
 	}
 
+	if options.RewriteManifest != nil && strings.EqualFold(file.Name, "META-INF/MANIFEST.MF") {
+		manifest, err := io.ReadAll(srcFile)
+		if err != nil {
+			return err
+		}
+		_, err = destFileWriter.Write(options.RewriteManifest(manifest))
+		return err
+	}
+
 	// Copy the file content
 	_, err = io.Copy(destFileWriter, srcFile)
 	return err
 }
+
+// IsJarSignatureFile reports whether a zip entry is a jar signature file
+// ("META-INF/*.SF", "*.RSA", "*.DSA", "*.EC" or "META-INF/SIG-*").
+func IsJarSignatureFile(name string) bool {
+	dir, base := path.Split(strings.ToUpper(name))
+	if dir != "META-INF/" {
+		return false
+	}
+
+	switch path.Ext(base) {
+	case ".SF", ".RSA", ".DSA", ".EC":
+		return true
+	}
+	return strings.HasPrefix(base, "SIG-")
+}
+
+// StripManifestDigests removes the digest attributes (e.g., "SHA-256-Digest") written by jarsigner from a manifest.
+// Per-entry sections that are left with only their "Name" attribute are removed entirely;
+// the main section and all other attributes are kept as they are, including continuation lines.
+func StripManifestDigests(manifest []byte) []byte {
+	newline := "\n"
+	if bytes.Contains(manifest, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(manifest), "\r\n", "\n"), "\n")
+
+	// Group the physical lines into sections of attributes; continuation lines start with a space
+	var sections [][][]string
+	var section [][]string
+	for _, line := range lines {
+		switch {
+		case line == "":
+			if section != nil {
+				sections = append(sections, section)
+				section = nil
+			}
+		case strings.HasPrefix(line, " ") && len(section) > 0:
+			section[len(section)-1] = append(section[len(section)-1], line)
+		default:
+			section = append(section, []string{line})
+		}
+	}
+	if section != nil {
+		sections = append(sections, section)
+	}
+
+	var result strings.Builder
+	for index, section := range sections {
+		kept := make([][]string, 0, len(section))
+		for _, attribute := range section {
+			attributeName, _, _ := strings.Cut(attribute[0], ":")
+			if strings.HasSuffix(strings.ToLower(attributeName), "-digest") {
+				continue
+			}
+			kept = append(kept, attribute)
+		}
+
+		// Drop per-entry sections without attributes besides their name
+		if index > 0 && (len(kept) == 0 || len(kept) == 1 && strings.HasPrefix(kept[0][0], "Name:")) {
+			continue
+		}
+
+		for _, attribute := range kept {
+			for _, line := range attribute {
+				result.WriteString(line)
+				result.WriteString(newline)
+			}
+		}
+		result.WriteString(newline)
+	}
+
+	return []byte(result.String())
+}
@@ -116,7 +116,7 @@ func TestMergeZips(t *testing.T) {
 
 	// Run the function we want to test
 	t.Run("success", func(t *testing.T) {
-		err := internal.MergeZips(context.Background(), baseZipPath, overlayZipPath, outputZipPath)
+		err := internal.MergeZips(context.Background(), baseZipPath, overlayZipPath, outputZipPath, internal.MergeOptions{})
 		if err != nil {
 			t.Fatalf("MergeZips failed: %v", err)
 		}
@@ -130,7 +130,7 @@ func TestMergeZips(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
-		err := internal.MergeZips(ctx, baseZipPath, overlayZipPath, outputZipPathCancelled)
+		err := internal.MergeZips(ctx, baseZipPath, overlayZipPath, outputZipPathCancelled, internal.MergeOptions{})
 		if err == nil {
 			t.Fatal("expected error for cancelled merge, but got nil")
 		}
@@ -141,3 +141,71 @@ func TestMergeZips(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeZipsStripSignatures(t *testing.T) {
+	tempDir := t.TempDir()
+
+	baseZipPath := filepath.Join(tempDir, "signed.jar")
+	overlayZipPath := filepath.Join(tempDir, "patch.zip")
+	outputZipPath := filepath.Join(tempDir, "server.jar")
+
+	signedManifest := "Manifest-Version: 1.0\r\n" +
+		"Main-Class: net.minecraft.server.MinecraftServer\r\n" +
+		"\r\n" +
+		"Name: net/minecraft/server/MinecraftServer.class\r\n" +
+		"SHA1-Digest: AAAAAAAAAAAAAAAAAAAAAAAAAAA=\r\n" +
+		"\r\n" +
+		"Name: net/minecraft/server/very/long/package/name/that/wraps/aroun\r\n" +
+		" d/Sealed.class\r\n" +
+		"SHA-256-Digest: BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB=\r\n" +
+		"Sealed: true\r\n" +
+		"\r\n"
+
+	createTestZip(t, baseZipPath, map[string]string{
+		"META-INF/MANIFEST.MF":                       signedManifest,
+		"META-INF/MOJANG_C.SF":                       "signature file",
+		"META-INF/MOJANG_C.DSA":                      "signature block",
+		"META-INF/SIG-TEST":                          "signature",
+		"META-INF/services/x":                        "service",
+		"net/minecraft/server/MinecraftServer.class": "vanilla class",
+	})
+	createTestZip(t, overlayZipPath, map[string]string{
+		"net/minecraft/server/MinecraftServer.class": "patched class",
+		"META-INF/FORGE.RSA":                         "stale patch signature",
+	})
+
+	if err := internal.MergeZips(context.Background(), baseZipPath, overlayZipPath, outputZipPath, internal.StripSignatures); err != nil {
+		t.Fatalf("MergeZips failed: %v", err)
+	}
+
+	verifyZipContent(t, outputZipPath, map[string]string{
+		"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\n" +
+			"Main-Class: net.minecraft.server.MinecraftServer\r\n" +
+			"\r\n" +
+			"Name: net/minecraft/server/very/long/package/name/that/wraps/aroun\r\n" +
+			" d/Sealed.class\r\n" +
+			"Sealed: true\r\n" +
+			"\r\n",
+		"META-INF/services/x":                        "service",
+		"net/minecraft/server/MinecraftServer.class": "patched class",
+	})
+}
+
+func TestIsJarSignatureFile(t *testing.T) {
+	testCases := map[string]bool{
+		"META-INF/MOJANG_C.SF":  true,
+		"META-INF/mojang_c.dsa": true,
+		"META-INF/CERT.RSA":     true,
+		"META-INF/KEY.EC":       true,
+		"META-INF/SIG-FOO":      true,
+		"META-INF/MANIFEST.MF":  false,
+		"META-INF/sub/CERT.RSA": false,
+		"CERT.SF":               false,
+		"META-INF/services/a":   false,
+	}
+	for name, want := range testCases {
+		if got := internal.IsJarSignatureFile(name); got != want {
+			t.Errorf("IsJarSignatureFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
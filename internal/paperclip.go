@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotPaperclip is returned by ApplyPaperclip for jars that are not Paperclip launchers (Paper 1.17 and older).
+var ErrNotPaperclip = errors.New("not a paperclip jar")
+
+// ApplyPaperclip performs the first-run setup of a Paperclip launcher jar without running it.
+// It downloads the vanilla server jar described by "META-INF/download-context" into "cache/",
+// applies the bsdiff patches of "META-INF/patches.list" and extracts the server jars and libraries
+// of the bundler lists into "versions/" and "libraries/". Every file is verified against its SHA-256 hash.
+// Afterwards the launcher finds everything in place and starts without network access.
+//
+// Parameters:
+//   - ctx: the context to control cancellation.
+//   - paperclipPath: the path of the Paperclip jar.
+//   - destDir: the server directory, i.e. the working directory the launcher will run in.
+//   - log: receives progress messages. It may be nil.
+//
+// Returns:
+//   - error: ErrNotPaperclip if the jar is not a Paperclip launcher, or an error if a download, patch or verification fails.
+func ApplyPaperclip(ctx context.Context, paperclipPath, destDir string, log func(format string, v ...any)) error {
+	if log == nil {
+		log = func(string, ...any) {}
+	}
+
+	paperclipZip, err := zip.OpenReader(paperclipPath)
+	if err != nil {
+		return err
+	}
+	defer paperclipZip.Close()
+	paperclip := ZipEntries(&paperclipZip.Reader)
+
+	if _, ok := paperclip["META-INF/download-context"]; !ok {
+		return ErrNotPaperclip
+	}
+
+	vanillaPath, err := downloadPaperclipContext(ctx, paperclip, destDir, log)
+	if err != nil {
+		return err
+	}
+
+	vanillaZip, err := zip.OpenReader(vanillaPath)
+	if err != nil {
+		return err
+	}
+	defer vanillaZip.Close()
+	vanilla := ZipEntries(&vanillaZip.Reader)
+
+	if err := applyPaperclipPatches(ctx, paperclip, vanilla, destDir, log); err != nil {
+		return err
+	}
+
+	// Files that were not patched are taken from the Paperclip jar itself, or from the vanilla jar
+	log("Extracting server and libraries...")
+	_, err = extractBundled(ctx, paperclip, destDir, paperclip, vanilla)
+	return err
+}
+
+// downloadPaperclipContext downloads the vanilla server jar described by "META-INF/download-context" into "cache/"
+// unless it is already present, and returns its path.
+func downloadPaperclipContext(ctx context.Context, paperclip map[string]*zip.File, destDir string, log func(format string, v ...any)) (string, error) {
+	data, err := ReadZipEntry(paperclip, "META-INF/download-context")
+	if err != nil {
+		return "", err
+	}
+
+	// The download context is a single line: hash, URL and file name separated by tabs
+	fields := strings.Split(strings.TrimSpace(string(data)), "\t")
+	if len(fields) != 3 {
+		return "", fmt.Errorf("invalid paperclip download context: %q", data)
+	}
+	expectedHash, url, fileName := fields[0], fields[1], fields[2]
+
+	cleanName, err := cleanListPath(fileName)
+	if err != nil {
+		return "", err
+	}
+	vanillaPath := filepath.Join(destDir, "cache", filepath.FromSlash(cleanName))
+	if VerifyFile(vanillaPath, "sha256", expectedHash) == nil {
+		return vanillaPath, Touch(vanillaPath)
+	}
+
+	log("Downloading vanilla server %s...", fileName)
+	if err := os.MkdirAll(filepath.Dir(vanillaPath), 0755); err != nil {
+		return "", err
+	}
+	if err := Download(ctx, url, vanillaPath, nil); err != nil {
+		return "", err
+	}
+	if err := VerifyFile(vanillaPath, "sha256", expectedHash); err != nil {
+		os.Remove(vanillaPath)
+		return "", err
+	}
+
+	return vanillaPath, nil
+}
+
+// applyPaperclipPatches applies the patches listed in "META-INF/patches.list".
+// Each line holds the location ("versions" or "libraries"), the original, patch and output hashes,
+// and the original, patch and output paths, separated by tabs.
+func applyPaperclipPatches(ctx context.Context, paperclip, vanilla map[string]*zip.File, destDir string, log func(format string, v ...any)) error {
+	if _, ok := paperclip["META-INF/patches.list"]; !ok {
+		return nil
+	}
+	data, err := ReadZipEntry(paperclip, "META-INF/patches.list")
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return fmt.Errorf("invalid line in patches.list: %q", line)
+		}
+		location, originalHash, patchHash, outputHash := fields[0], fields[1], fields[2], fields[3]
+		originalPath, patchPath, outputPath := fields[4], fields[5], fields[6]
+
+		cleanOutput, err := cleanListPath(location + "/" + outputPath)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(destDir, filepath.FromSlash(cleanOutput))
+		if VerifyFile(targetPath, "sha256", outputHash) == nil {
+			if err := Touch(targetPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		original, err := readVerifiedEntry(vanilla, "META-INF/"+location+"/"+originalPath, originalHash)
+		if err != nil {
+			return err
+		}
+		patch, err := readVerifiedEntry(paperclip, "META-INF/"+location+"/"+patchPath, patchHash)
+		if err != nil {
+			return err
+		}
+
+		log("Applying patch %s...", patchPath)
+		output, err := ApplyBsdiff(original, patch)
+		if err != nil {
+			return fmt.Errorf("failed to apply patch %s: %w", patchPath, err)
+		}
+		if err := verifyBytes(output, outputHash); err != nil {
+			return fmt.Errorf("patched %s: %w", outputPath, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(targetPath, output, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readVerifiedEntry reads a zip entry and verifies its SHA-256 hash.
+func readVerifiedEntry(files map[string]*zip.File, name, expectedHash string) ([]byte, error) {
+	data, err := ReadZipEntry(files, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBytes(data, expectedHash); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return data, nil
+}
+
+// verifyBytes verifies the SHA-256 hash of data.
+func verifyBytes(data []byte, expectedHash string) error {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(actual, strings.TrimSpace(expectedHash)) {
+		return fmt.Errorf("sha256 mismatch: expected %s, got %s", expectedHash, actual)
+	}
+	return nil
+}
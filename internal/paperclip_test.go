@@ -0,0 +1,88 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestApplyPaperclip(t *testing.T) {
+	tempDir := t.TempDir()
+
+	const mojangLibrary = "mojang library"
+	const paperLibrary = "paper library"
+
+	// The vanilla jar is a bundler holding the original server jar and a library
+	vanillaPath := filepath.Join(tempDir, "vanilla.jar")
+	createTestZip(t, vanillaPath, map[string]string{
+		"META-INF/versions/1.21/server-1.21.jar":    testBsdiffOld,
+		"META-INF/libraries/com/mojang/lib-1.0.jar": mojangLibrary,
+	})
+	vanillaJar, err := os.ReadFile(vanillaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var downloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.Write(vanillaJar)
+	}))
+	defer server.Close()
+
+	patch := testBsdiffPatchBytes(t)
+	paperclipPath := filepath.Join(tempDir, "server.jar")
+	createTestZip(t, paperclipPath, map[string]string{
+		"META-INF/main-class":       "io.papermc.paper.PaperBootstrap",
+		"META-INF/download-context": fmt.Sprintf("%s\t%s/server.jar\tmojang_1.21.jar\n", sha256Hex(string(vanillaJar)), server.URL),
+		"META-INF/patches.list": fmt.Sprintf("versions\t%s\t%s\t%s\t1.21/server-1.21.jar\t1.21/paper-1.21.jar.patch\t1.21/paper-1.21.jar\n",
+			sha256Hex(testBsdiffOld), sha256Hex(string(patch)), sha256Hex(testBsdiffNew)),
+		"META-INF/versions/1.21/paper-1.21.jar.patch": string(patch),
+		"META-INF/versions.list":                      fmt.Sprintf("%s\t1.21\t1.21/paper-1.21.jar\n", sha256Hex(testBsdiffNew)),
+		"META-INF/libraries.list": fmt.Sprintf("%s\tcom.mojang:lib:1.0\tcom/mojang/lib-1.0.jar\n%s\tio.papermc:lib:1.0\tio/papermc/lib-1.0.jar\n",
+			sha256Hex(mojangLibrary), sha256Hex(paperLibrary)),
+		"META-INF/libraries/io/papermc/lib-1.0.jar": paperLibrary,
+	})
+
+	destDir := filepath.Join(tempDir, "server")
+	if err := internal.ApplyPaperclip(context.Background(), paperclipPath, destDir, nil); err != nil {
+		t.Fatalf("ApplyPaperclip failed: %v", err)
+	}
+
+	expectedFiles := map[string]string{
+		"cache/mojang_1.21.jar":            string(vanillaJar),
+		"versions/1.21/paper-1.21.jar":     testBsdiffNew,
+		"libraries/com/mojang/lib-1.0.jar": mojangLibrary,
+		"libraries/io/papermc/lib-1.0.jar": paperLibrary,
+	}
+	for name, want := range expectedFiles {
+		content, err := os.ReadFile(filepath.Join(destDir, filepath.FromSlash(name)))
+		if err != nil || string(content) != want {
+			t.Errorf("unexpected content for %s: %q (%v)", name, content, err)
+		}
+	}
+
+	t.Run("second run is offline", func(t *testing.T) {
+		server.Close()
+		if err := internal.ApplyPaperclip(context.Background(), paperclipPath, destDir, nil); err != nil {
+			t.Fatalf("expected an up-to-date install to succeed offline, got: %v", err)
+		}
+		if downloads != 1 {
+			t.Errorf("expected the vanilla jar to be downloaded once, got %d downloads", downloads)
+		}
+	})
+
+	t.Run("not a paperclip jar", func(t *testing.T) {
+		err := internal.ApplyPaperclip(context.Background(), vanillaPath, filepath.Join(tempDir, "other"), nil)
+		if !errors.Is(err, internal.ErrNotPaperclip) {
+			t.Fatalf("expected ErrNotPaperclip, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// dottedVersion matches dotted versions such as game releases ("1.21.4") and loader versions ("47.3.0"),
+// optionally followed by a pre-release suffix (e.g., "21.4.100-beta" or "1.21-pre1").
+var dottedVersion = regexp.MustCompile(`^(\d+(?:\.\d+)*)(?:-(.+))?$`)
+
+// CompareVersions compares two dotted versions numerically, like slices.Compare (e.g., "14.23.5.999" < "14.23.5.2860").
+// A version with a pre-release suffix comes before the same version without one
+// (e.g., "21.4.100-beta" < "21.4.100" < "21.4.101-beta").
+// Versions that are not dotted (e.g., the snapshot "24w14a") compare as equal to every other version,
+// so that they are never replaced by a version they cannot be ordered against.
+func CompareVersions(a, b string) int {
+	matchA, matchB := dottedVersion.FindStringSubmatch(a), dottedVersion.FindStringSubmatch(b)
+	if matchA == nil || matchB == nil {
+		return 0
+	}
+	if order := slices.Compare(versionNumbers(matchA[1]), versionNumbers(matchB[1])); order != 0 {
+		return order
+	}
+
+	switch suffixA, suffixB := matchA[2], matchB[2]; {
+	case suffixA == suffixB:
+		return 0
+	case suffixA == "":
+		return 1
+	case suffixB == "":
+		return -1
+	default:
+		return compareSuffixes(suffixA, suffixB)
+	}
+}
+
+// suffixPart matches the runs of digits and of letters in a pre-release suffix (e.g., "pre", "10").
+var suffixPart = regexp.MustCompile(`\d+|[^\d.+-]+`)
+
+// compareSuffixes compares two pre-release suffixes part by part, numbers numerically and words alphabetically,
+// so that "pre2" < "pre10" < "rc1".
+func compareSuffixes(a, b string) int {
+	partsA, partsB := suffixPart.FindAllString(a, -1), suffixPart.FindAllString(b, -1)
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		order := 0
+		switch {
+		case errA == nil && errB == nil:
+			order = cmp.Compare(numberA, numberB)
+		case errA == nil:
+			// Numbers come before words, like in semantic versioning
+			order = -1
+		case errB == nil:
+			order = 1
+		default:
+			order = strings.Compare(partsA[i], partsB[i])
+		}
+		if order != 0 {
+			return order
+		}
+	}
+	return cmp.Compare(len(partsA), len(partsB))
+}
+
+// versionNumbers returns the numbers of a dotted version.
+func versionNumbers(version string) []int {
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		number, _ := strconv.Atoi(part)
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
@@ -0,0 +1,35 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.21.4", "1.21.10", -1},
+		{"1.21", "1.21.0", -1},
+		{"14.23.5.2860", "14.23.5.999", 1},
+		{"21.4.100-beta", "21.4.99-beta", 1},
+		{"21.4.100-beta", "21.4.100", -1},
+		{"1.21-pre2", "1.21-pre1", 1},
+		{"1.21-pre10", "1.21-pre2", 1},
+		{"1.21-rc1", "1.21-pre9", 1},
+		{"1.21-rc1", "1.21-rc1", 0},
+		{"21.0.10-beta", "21.0.9-beta", 1},
+		{"1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{"1.0.0-beta", "1.0.0-beta.1", -1},
+		{"47.3.0", "47.3.0", 0},
+		{"24w14a", "1.21.4", 0},
+	}
+
+	for _, tc := range testCases {
+		if got := internal.CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package detect identifies the server type and version of an existing server directory from its jar,
+// for directories that were not installed with a lock file.
+package detect
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// ErrNoServer is returned by Detect when a directory contains no server jar.
+var ErrNoServer = errors.New("no server jar found")
+
+// ErrUnknown is returned when a jar is not a server jar of a known type.
+var ErrUnknown = errors.New("unknown server type")
+
+// Result describes a detected server.
+type Result struct {
+	// Provider is the canonical server type name (e.g., "paper").
+	Provider string
+
+	// GameVersion is the Minecraft version (e.g., "1.21.4").
+	GameVersion string
+
+	// ServerVersion is the loader version or build, if the type has one and the server records it.
+	ServerVersion string
+
+	// Jar is the path of the detected jar relative to the server directory, with forward slashes.
+	// It is empty for servers detected from their libraries (e.g., Forge for Minecraft 1.17 and newer).
+	Jar string
+}
+
+// Detect identifies the server in a directory. The jars at the top level of the directory are inspected
+// with DetectJar, "server.jar" first; a modded server or fork is preferred over a vanilla jar next to it.
+// Forge and NeoForge servers of Minecraft 1.17 and newer, which run without a server jar, are recognized
+// by the directory their installer created in "libraries/".
+//
+// Parameters:
+//   - dir: the server directory.
+//
+// Returns:
+//   - *Result: the detected server.
+//   - error: ErrNoServer if there is no server, ErrUnknown if no jar is a known server, or an error if a jar cannot be read.
+func Detect(dir string) (*Result, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var jars []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".jar") {
+			jars = append(jars, entry.Name())
+		}
+	}
+	if i := slices.Index(jars, "server.jar"); i > 0 {
+		jars[0], jars[i] = jars[i], jars[0]
+	}
+
+	var vanilla *Result
+	for _, jar := range jars {
+		result, err := DetectJar(filepath.Join(dir, jar))
+		if errors.Is(err, ErrUnknown) || errors.Is(err, zip.ErrFormat) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if result.Provider != "vanilla" {
+			return result, nil
+		}
+		if vanilla == nil {
+			vanilla = result
+		}
+	}
+
+	if result, err := detectLibraries(dir); result != nil || err != nil {
+		return result, err
+	}
+	if vanilla != nil {
+		return vanilla, nil
+	}
+	if len(jars) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNoServer)
+	}
+	return nil, fmt.Errorf("%s: %w", dir, ErrUnknown)
+}
+
+// forgeLibraryDirs are the directories of the Forge and NeoForge libraries that installers create,
+// with one subdirectory per installed version.
+var forgeLibraryDirs = []struct {
+	group, artifact string
+}{
+	{"net.neoforged", "neoforge"},
+	{"net.neoforged", "forge"},
+	{"net.minecraftforge", "forge"},
+}
+
+// detectLibraries recognizes a Forge or NeoForge server by its version directory in "libraries/"
+// (e.g., "libraries/net/minecraftforge/forge/1.20.1-47.3.0"). The newest version wins if several are installed.
+func detectLibraries(dir string) (*Result, error) {
+	for _, library := range forgeLibraryDirs {
+		libraryDir := filepath.Join(dir, "libraries", filepath.FromSlash(strings.ReplaceAll(library.group, ".", "/")), library.artifact)
+		entries, err := os.ReadDir(libraryDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var newest *Result
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			result := forgeLibrary(library.group, library.artifact, entry.Name(), "")
+			if result != nil && (newest == nil || internal.CompareVersions(result.ServerVersion, newest.ServerVersion) > 0) {
+				newest = result
+			}
+		}
+		if newest != nil {
+			return newest, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,242 @@
+package detect_test
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/detect"
+)
+
+// createJar writes a jar with the given entries.
+func createJar(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetectJar(t *testing.T) {
+	tests := []struct {
+		name     string
+		jar      string
+		entries  map[string]string
+		files    map[string]map[string]string
+		expected detect.Result
+	}{
+		{
+			name:     "vanilla",
+			jar:      "server.jar",
+			entries:  map[string]string{"version.json": `{"id": "1.21.4", "world_version": 4189}`, "META-INF/MANIFEST.MF": "Main-Class: net.minecraft.bundler.Main\n"},
+			expected: detect.Result{Provider: "vanilla", GameVersion: "1.21.4"},
+		},
+		{
+			name: "paperclip",
+			jar:  "server.jar",
+			entries: map[string]string{
+				"META-INF/download-context": "abc\thttps://piston-data.mojang.com/server.jar\tmojang_1.21.4.jar\n",
+				"META-INF/versions.list":    "def\t1.21.4\t1.21.4/purpur-1.21.4.jar\n",
+			},
+			files: map[string]map[string]string{
+				"versions/1.21.4/purpur-1.21.4.jar": {"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Version: 1.21.4-2416-ver/1.21.4@0a1b2c3\n"},
+			},
+			expected: detect.Result{Provider: "purpur", GameVersion: "1.21.4", ServerVersion: "2416"},
+		},
+		{
+			name: "legacy paperclip",
+			jar:  "paper.jar",
+			entries: map[string]string{
+				"patch.properties":     "patch=paperMC.patch\nversion=1.16.5\n",
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nImplementation-Title: Paper\nImplementation-Version: git-Paper-794 (MC: 1.16.5)\n",
+			},
+			expected: detect.Result{Provider: "paper", GameVersion: "1.16.5", ServerVersion: "794"},
+		},
+		{
+			name:     "patched paper",
+			jar:      "paper-1.21.4.jar",
+			entries:  map[string]string{"version.json": `{"id": "1.21.4"}`, "META-INF/MANIFEST.MF": "Brand-Id: papermc:paper\nImplementation-Version: 1.21.4-232-c5c2ae6 (MC: 1.21.4)\n"},
+			expected: detect.Result{Provider: "paper", GameVersion: "1.21.4", ServerVersion: "232"},
+		},
+		{
+			name:     "fabric launcher",
+			jar:      "server.jar",
+			entries:  map[string]string{"install.properties": "fabric-loader-version=0.16.14\ngame-version=1.21.5\n"},
+			expected: detect.Result{Provider: "fabric", GameVersion: "1.21.5", ServerVersion: "0.16.14"},
+		},
+		{
+			name:     "legacy fabric launcher",
+			jar:      "server.jar",
+			entries:  map[string]string{"install.properties": "fabric-loader-version=0.16.14\ngame-version=1.8.9\n"},
+			expected: detect.Result{Provider: "legacyfabric", GameVersion: "1.8.9", ServerVersion: "0.16.14"},
+		},
+		{
+			name: "fabric installer launcher",
+			jar:  "fabric-server-launch.jar",
+			entries: map[string]string{
+				"fabric-server-launch.properties": "launch.mainClass=net.fabricmc.loader.impl.launch.knot.KnotServer\nserverJar=vanilla.jar\n",
+				// The Class-Path is wrapped at 72 bytes, continuing with a leading space
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nClass-Path: libraries/net/fabricmc/fabric-loader/0.14.21/fabric-loader-0.1\n 4.21.jar libraries/org/ow2/asm/asm/9.5/asm-9.5.jar\n",
+			},
+			files:    map[string]map[string]string{"vanilla.jar": {"version.json": `{"id": "1.20.1"}`}},
+			expected: detect.Result{Provider: "fabric", GameVersion: "1.20.1", ServerVersion: "0.14.21"},
+		},
+		{
+			name:     "forge installer",
+			jar:      "installer.jar",
+			entries:  map[string]string{"install_profile.json": `{"spec": 1, "version": "1.20.1-forge-47.3.0", "path": "net.minecraftforge:forge:1.20.1-47.3.0:shim", "minecraft": "1.20.1"}`},
+			expected: detect.Result{Provider: "forge", GameVersion: "1.20.1", ServerVersion: "47.3.0"},
+		},
+		{
+			name:     "legacy forge installer",
+			jar:      "installer.jar",
+			entries:  map[string]string{"install_profile.json": `{"install": {"path": "net.minecraftforge:forge:1.7.10-10.13.4.1614-1.7.10", "minecraft": "1.7.10"}}`},
+			expected: detect.Result{Provider: "forge", GameVersion: "1.7.10", ServerVersion: "10.13.4.1614"},
+		},
+		{
+			name:     "neoforge installer",
+			jar:      "installer.jar",
+			entries:  map[string]string{"install_profile.json": `{"spec": 1, "version": "neoforge-21.1.77", "path": "net.neoforged:neoforge:21.1.77:shim", "minecraft": "1.21.1"}`},
+			expected: detect.Result{Provider: "neoforge", GameVersion: "1.21.1", ServerVersion: "21.1.77"},
+		},
+		{
+			name: "merged forge server",
+			jar:  "server.jar",
+			entries: map[string]string{
+				"forgeversion.properties": "forge.major.number=7\nforge.minor.number=8\nforge.revision.number=1\nforge.build.number=738\n",
+				"fmlversion.properties":   "fmlbuild.mcversion=1.5.2\n",
+			},
+			expected: detect.Result{Provider: "forge", GameVersion: "1.5.2", ServerVersion: "7.8.1.738"},
+		},
+		{
+			name:     "forge jar",
+			jar:      "forge-1.16.5-36.2.39.jar",
+			entries:  map[string]string{"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n"},
+			expected: detect.Result{Provider: "forge", GameVersion: "1.16.5", ServerVersion: "36.2.39"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			createJar(t, filepath.Join(dir, test.jar), test.entries)
+			for name, entries := range test.files {
+				if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+					t.Fatal(err)
+				}
+				createJar(t, filepath.Join(dir, name), entries)
+			}
+
+			result, err := detect.DetectJar(filepath.Join(dir, test.jar))
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			test.expected.Jar = test.jar
+			if *result != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, *result)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Run("fork over vanilla", func(t *testing.T) {
+		dir := t.TempDir()
+		createJar(t, filepath.Join(dir, "minecraft_server.1.16.5.jar"), map[string]string{"version.json": `{"id": "1.16.5"}`})
+		createJar(t, filepath.Join(dir, "server.jar"), map[string]string{"version.json": `{"id": "1.16.5"}`})
+		createJar(t, filepath.Join(dir, "forge-1.16.5-36.2.39.jar"), map[string]string{"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n"})
+		if err := os.WriteFile(filepath.Join(dir, "notes.jar"), []byte("not a zip"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := detect.Detect(dir)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		expected := detect.Result{Provider: "forge", GameVersion: "1.16.5", ServerVersion: "36.2.39", Jar: "forge-1.16.5-36.2.39.jar"}
+		if *result != expected {
+			t.Errorf("unexpected result %+v", *result)
+		}
+	})
+
+	t.Run("vanilla", func(t *testing.T) {
+		dir := t.TempDir()
+		createJar(t, filepath.Join(dir, "minecraft_server.jar"), map[string]string{"version.json": `{"id": "1.20.1"}`})
+
+		result, err := detect.Detect(dir)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		expected := detect.Result{Provider: "vanilla", GameVersion: "1.20.1", Jar: "minecraft_server.jar"}
+		if *result != expected {
+			t.Errorf("unexpected result %+v", *result)
+		}
+	})
+
+	t.Run("forge libraries", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, version := range []string{"1.20.1-47.2.0", "1.20.1-47.3.0"} {
+			if err := os.MkdirAll(filepath.Join(dir, "libraries", "net", "minecraftforge", "forge", version), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		result, err := detect.Detect(dir)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		expected := detect.Result{Provider: "forge", GameVersion: "1.20.1", ServerVersion: "47.3.0"}
+		if *result != expected {
+			t.Errorf("unexpected result %+v", *result)
+		}
+	})
+
+	t.Run("neoforge beta libraries", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, version := range []string{"21.4.99-beta", "21.4.100-beta", "21.4.98"} {
+			if err := os.MkdirAll(filepath.Join(dir, "libraries", "net", "neoforged", "neoforge", version), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		result, err := detect.Detect(dir)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		expected := detect.Result{Provider: "neoforge", GameVersion: "1.21.4", ServerVersion: "21.4.100-beta"}
+		if *result != expected {
+			t.Errorf("unexpected result %+v", *result)
+		}
+	})
+
+	t.Run("no server", func(t *testing.T) {
+		if _, err := detect.Detect(t.TempDir()); !errors.Is(err, detect.ErrNoServer) {
+			t.Errorf("expected ErrNoServer, got %v", err)
+		}
+	})
+
+	t.Run("unknown jar", func(t *testing.T) {
+		dir := t.TempDir()
+		createJar(t, filepath.Join(dir, "server.jar"), map[string]string{"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n"})
+		if _, err := detect.Detect(dir); !errors.Is(err, detect.ErrUnknown) {
+			t.Errorf("expected ErrUnknown, got %v", err)
+		}
+	})
+}
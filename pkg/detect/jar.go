@@ -0,0 +1,376 @@
+package detect
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/neoforge"
+	"github.com/abulleDev/mcserverdl/v2/pkg/serverconfig"
+)
+
+// Build numbers in the Implementation-Version of Paper and its forks,
+// e.g. "git-Paper-232 (MC: 1.20.4)" in older builds and "1.21.4-232-c5c2ae6 (MC: 1.21.4)" in newer ones.
+var (
+	legacyBuildPattern = regexp.MustCompile(`^git-[A-Za-z]+-(\d+)\b`)
+	buildPattern       = regexp.MustCompile(`^\d+(?:\.\d+)+-(\d+)\b`)
+)
+
+// Names of the Forge jars that the Forge installers of Minecraft 1.13 to 1.16 and
+// the very old universal downloads leave in the server directory.
+var (
+	forgeJarPattern       = regexp.MustCompile(`^forge-(\d[^-]*)-([^-]+?)(?:-universal|-server|-shim)?\.jar$`)
+	legacyForgeJarPattern = regexp.MustCompile(`^minecraftforge-(?:universal|server)-(\d[^-]*)-([^-]+)\.jar$`)
+)
+
+// ParseForgeJar parses the name of a Forge server jar that the Forge installers of Minecraft 1.16 and older leave
+// in the server directory (e.g., "forge-1.12.2-14.23.5.2860.jar"), which launches the server.
+// ok is false if the name is not that of a Forge jar.
+func ParseForgeJar(name string) (gameVersion, forgeVersion string, ok bool) {
+	for _, pattern := range []*regexp.Regexp{forgeJarPattern, legacyForgeJarPattern} {
+		if match := pattern.FindStringSubmatch(name); match != nil {
+			return match[1], match[2], true
+		}
+	}
+	return "", "", false
+}
+
+// fabricLoaderPattern finds the Fabric Loader library in the Class-Path of a Fabric server launcher.
+var fabricLoaderPattern = regexp.MustCompile(`fabric-loader-([^/\s]+?)\.jar`)
+
+// DetectJar identifies the server of a jar. It recognizes, in order:
+//   - Forge and NeoForge installers, by their "install_profile.json".
+//   - Paperclip launchers of Paper and its forks (e.g., Purpur), by "META-INF/download-context" or "patch.properties".
+//   - Fabric and Legacy Fabric server launchers, by "install.properties" or "fabric-server-launch.properties".
+//   - Old Forge server jars, by "forgeversion.properties" or their file name.
+//   - Server jars of Paper and its forks that were already patched, by their manifest.
+//   - Mojang server jars of Minecraft 1.14 and newer, by "version.json".
+//
+// Parameters:
+//   - path: the path of the jar.
+//
+// Returns:
+//   - *Result: the detected server. Jar is the base name of path.
+//   - error: ErrUnknown if the jar is not a known server jar, or an error if it cannot be read.
+func DetectJar(path string) (*Result, error) {
+	jar, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer jar.Close()
+	files := internal.ZipEntries(&jar.Reader)
+	manifest := readManifest(files)
+
+	detectors := []func() (*Result, error){
+		func() (*Result, error) { return detectInstaller(files) },
+		func() (*Result, error) { return detectPaperclip(files, manifest, filepath.Dir(path)) },
+		func() (*Result, error) { return detectFabric(files, manifest, filepath.Dir(path)) },
+		func() (*Result, error) { return detectForge(files, filepath.Base(path)) },
+		func() (*Result, error) { return detectVanilla(files, manifest) },
+	}
+	for _, detector := range detectors {
+		result, err := detector()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if result != nil {
+			result.Jar = filepath.Base(path)
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", path, ErrUnknown)
+}
+
+// detectInstaller recognizes Forge and NeoForge installers by the library their "install_profile.json" installs.
+// Installers up to Minecraft 1.12 nest the profile in "install".
+func detectInstaller(files map[string]*zip.File) (*Result, error) {
+	if _, ok := files["install_profile.json"]; !ok {
+		return nil, nil
+	}
+
+	var profile struct {
+		Path      string `json:"path"`
+		Minecraft string `json:"minecraft"`
+		Install   *struct {
+			Path      string `json:"path"`
+			Minecraft string `json:"minecraft"`
+		} `json:"install"`
+	}
+	if err := readJSON(files, "install_profile.json", &profile); err != nil {
+		return nil, err
+	}
+	if profile.Install != nil {
+		profile.Path, profile.Minecraft = profile.Install.Path, profile.Install.Minecraft
+	}
+
+	coordinate, err := maven.ParseCoordinate(profile.Path)
+	if err != nil {
+		return nil, nil
+	}
+	return forgeLibrary(coordinate.Group, coordinate.Artifact, coordinate.Version, profile.Minecraft), nil
+}
+
+// detectPaperclip recognizes the Paperclip launchers of Paper and its forks. The fork is named by the server jar
+// in "META-INF/versions.list" (e.g., "purpur-1.21.4.jar"), and the build is read from the manifest of the
+// launcher or of the patched jar in the server directory.
+func detectPaperclip(files map[string]*zip.File, manifest map[string]string, dir string) (*Result, error) {
+	// Paperclip for Minecraft 1.17 and older describes the patch in "patch.properties"
+	if _, ok := files["patch.properties"]; ok {
+		properties, err := readProperties(files, "patch.properties")
+		if err != nil {
+			return nil, err
+		}
+		gameVersion, _ := properties.Get("version")
+		result := &Result{Provider: forkName(manifest, "paper"), GameVersion: gameVersion}
+		result.ServerVersion = buildNumber(manifest)
+		if result.ServerVersion == "" {
+			result.ServerVersion = buildNumber(jarManifest(filepath.Join(dir, "cache", "patched_"+gameVersion+".jar")))
+		}
+		return result, nil
+	}
+
+	if _, ok := files["META-INF/download-context"]; !ok {
+		return nil, nil
+	}
+	versions, err := internal.ReadBundlerList(files, "META-INF/versions.list")
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("META-INF/versions.list is empty")
+	}
+
+	// The server jar is listed as "<fork>-<game version>.jar", possibly below a "<game version>/" directory
+	server := versions[0]
+	fork := strings.ToLower(strings.TrimSuffix(filepath.Base(server.Path), "-"+server.ID+".jar"))
+	if fork == "" || strings.HasSuffix(fork, ".jar") {
+		fork = forkName(manifest, "paper")
+	}
+	result := &Result{Provider: fork, GameVersion: server.ID}
+	result.ServerVersion = buildNumber(manifest)
+	if result.ServerVersion == "" {
+		result.ServerVersion = buildNumber(jarManifest(filepath.Join(dir, "versions", filepath.FromSlash(server.Path))))
+	}
+	return result, nil
+}
+
+// detectFabric recognizes the server launchers of Fabric: the launcher built by the Fabric meta API
+// records the versions in "install.properties", while the launcher written by the Fabric installer
+// refers to the vanilla jar in "fabric-server-launch.properties" and to the loader in its Class-Path.
+// Game versions older than 1.14 are those of Legacy Fabric.
+func detectFabric(files map[string]*zip.File, manifest map[string]string, dir string) (*Result, error) {
+	var gameVersion, loaderVersion string
+	switch {
+	case files["install.properties"] != nil:
+		properties, err := readProperties(files, "install.properties")
+		if err != nil {
+			return nil, err
+		}
+		gameVersion, _ = properties.Get("game-version")
+		loaderVersion, _ = properties.Get("fabric-loader-version")
+	case files["fabric-server-launch.properties"] != nil:
+		properties, err := readProperties(files, "fabric-server-launch.properties")
+		if err != nil {
+			return nil, err
+		}
+		serverJar, ok := properties.Get("serverJar")
+		if !ok {
+			serverJar = "server.jar"
+		}
+		if vanilla, err := DetectJar(filepath.Join(dir, filepath.FromSlash(serverJar))); err == nil {
+			gameVersion = vanilla.GameVersion
+		}
+		if match := fabricLoaderPattern.FindStringSubmatch(manifest["Class-Path"]); match != nil {
+			loaderVersion = match[1]
+		}
+	default:
+		return nil, nil
+	}
+
+	// Compare against the first 1.14 pre-release, so that the pre-releases of 1.14 are not taken for older versions
+	provider := "fabric"
+	if internal.CompareVersions(gameVersion, "1.14-pre1") < 0 {
+		provider = "legacyfabric"
+	}
+	return &Result{Provider: provider, GameVersion: gameVersion, ServerVersion: loaderVersion}, nil
+}
+
+// detectForge recognizes the server jars of old Forge versions, which record their version in
+// "forgeversion.properties", and the Forge jars the installers of Minecraft 1.13 to 1.16 name after the versions.
+func detectForge(files map[string]*zip.File, name string) (*Result, error) {
+	if _, ok := files["forgeversion.properties"]; ok {
+		properties, err := readProperties(files, "forgeversion.properties")
+		if err != nil {
+			return nil, err
+		}
+		var parts []string
+		for _, key := range []string{"forge.major.number", "forge.minor.number", "forge.revision.number", "forge.build.number"} {
+			if value, ok := properties.Get(key); ok {
+				parts = append(parts, value)
+			}
+		}
+		gameVersion, ok := properties.Get("forge.mc.version")
+		if !ok && files["fmlversion.properties"] != nil {
+			fml, err := readProperties(files, "fmlversion.properties")
+			if err != nil {
+				return nil, err
+			}
+			gameVersion, _ = fml.Get("fmlbuild.mcversion")
+		}
+		return &Result{Provider: "forge", GameVersion: gameVersion, ServerVersion: strings.Join(parts, ".")}, nil
+	}
+
+	if gameVersion, forgeVersion, ok := ParseForgeJar(name); ok {
+		return &Result{Provider: "forge", GameVersion: gameVersion, ServerVersion: forgeVersion}, nil
+	}
+	return nil, nil
+}
+
+// detectVanilla recognizes Mojang server jars by "version.json", which Minecraft 1.14 and newer include.
+// Server jars of Paper and its forks include it as well, and are told apart by the fork named in their manifest.
+func detectVanilla(files map[string]*zip.File, manifest map[string]string) (*Result, error) {
+	if _, ok := files["version.json"]; !ok {
+		return nil, nil
+	}
+
+	var version struct {
+		ID string `json:"id"`
+	}
+	if err := readJSON(files, "version.json", &version); err != nil {
+		return nil, err
+	}
+	if version.ID == "" {
+		return nil, nil
+	}
+
+	if fork := forkName(manifest, ""); fork != "" {
+		return &Result{Provider: fork, GameVersion: version.ID, ServerVersion: buildNumber(manifest)}, nil
+	}
+	return &Result{Provider: "vanilla", GameVersion: version.ID}, nil
+}
+
+// forgeLibrary returns the server of a Forge or NeoForge library version (e.g., "net.minecraftforge:forge:1.20.1-47.3.0"),
+// or nil for other libraries. gameVersion may be empty if the version includes it.
+func forgeLibrary(group, artifact, version, gameVersion string) *Result {
+	switch {
+	case group == "net.neoforged" && artifact == "neoforge":
+		if gameVersion == "" {
+			gameVersion, _ = neoforge.ParseGameVersion(version)
+		}
+		return &Result{Provider: "neoforge", GameVersion: gameVersion, ServerVersion: version}
+	case group == "net.minecraftforge" && artifact == "forge", group == "net.neoforged" && artifact == "forge":
+		// Forge versions are "<game version>-<loader version>[-<branch>]";
+		// NeoForge for Minecraft 1.20.1 kept the Forge artifact
+		provider := "forge"
+		if group == "net.neoforged" {
+			provider = "neoforge"
+		}
+		parts := strings.SplitN(version, "-", 3)
+		if len(parts) < 2 {
+			return nil
+		}
+		if gameVersion == "" {
+			gameVersion = parts[0]
+		}
+		return &Result{Provider: provider, GameVersion: gameVersion, ServerVersion: parts[1]}
+	default:
+		return nil
+	}
+}
+
+// forkName returns the server type named by a manifest (e.g., "purpur" for "Brand-Id: purpurmc:purpur"
+// or "Implementation-Title: Purpur"), or fallback if the manifest names none.
+func forkName(manifest map[string]string, fallback string) string {
+	if brand := manifest["Brand-Id"]; brand != "" {
+		_, name, _ := strings.Cut(brand, ":")
+		return strings.ToLower(name)
+	}
+	title := manifest["Implementation-Title"]
+	if title != "" && !strings.EqualFold(title, "Minecraft") && !strings.Contains(strings.ToLower(title), "paperclip") {
+		return strings.ToLower(strings.Fields(title)[0])
+	}
+	return fallback
+}
+
+// buildNumber returns the build number in the Implementation-Version of a manifest, or an empty string.
+func buildNumber(manifest map[string]string) string {
+	version := manifest["Implementation-Version"]
+	for _, pattern := range []*regexp.Regexp{legacyBuildPattern, buildPattern} {
+		if match := pattern.FindStringSubmatch(version); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// jarManifest returns the main attributes of the manifest of a jar file, or nil if it cannot be read.
+func jarManifest(path string) map[string]string {
+	jar, err := zip.OpenReader(path)
+	if err != nil {
+		return nil
+	}
+	defer jar.Close()
+	return readManifest(internal.ZipEntries(&jar.Reader))
+}
+
+// readManifest parses the main attributes of "META-INF/MANIFEST.MF", joining continuation lines.
+// A missing or unreadable manifest has no attributes.
+func readManifest(files map[string]*zip.File) map[string]string {
+	data, err := internal.ReadZipEntry(files, "META-INF/MANIFEST.MF")
+	if err != nil {
+		return nil
+	}
+
+	attributes := map[string]string{}
+	var lastKey string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			// The main attributes end at the first blank line
+			break
+		}
+		if strings.HasPrefix(line, " ") && lastKey != "" {
+			attributes[lastKey] += line[1:]
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Values may continue with a leading space on the next line, so only the separator's space is removed
+		lastKey = strings.TrimSpace(key)
+		attributes[lastKey] = strings.TrimPrefix(value, " ")
+	}
+	return attributes
+}
+
+// readProperties parses a properties entry of a jar.
+func readProperties(files map[string]*zip.File, name string) (*serverconfig.Properties, error) {
+	data, err := internal.ReadZipEntry(files, name)
+	if err != nil {
+		return nil, err
+	}
+	return serverconfig.ParseProperties(bytes.NewReader(data))
+}
+
+// readJSON decodes a JSON entry of a jar.
+func readJSON(files map[string]*zip.File, name string, value any) error {
+	data, err := internal.ReadZipEntry(files, name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package factory
+
+import (
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/bedrock"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/fabric"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/forge"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/githubrelease"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/jenkins"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/neoforge"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/paper"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/purpur"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
+
+// Register the server types shipped with this module.
+func init() {
+	Register("vanilla", func() provider.Provider { return vanilla.New() }, ProviderInfo{
+		DisplayName: "Vanilla",
+		Homepage:    "https://www.minecraft.net/download/server",
+		Aliases:     []string{"mojang"},
+	})
+	Register("paper", func() provider.Provider { return paper.New() }, ProviderInfo{
+		DisplayName:       "Paper",
+		Homepage:          "https://papermc.io",
+		HasServerVersions: true,
+	})
+	Register("fabric", func() provider.Provider { return fabric.New() }, ProviderInfo{
+		DisplayName:       "Fabric",
+		Homepage:          "https://fabricmc.net",
+		HasServerVersions: true,
+	})
+	Register("legacyfabric", func() provider.Provider { return fabric.NewLegacy() }, ProviderInfo{
+		DisplayName:       "Legacy Fabric",
+		Homepage:          "https://legacyfabric.net",
+		Aliases:           []string{"legacy-fabric"},
+		HasServerVersions: true,
+	})
+	Register("forge", func() provider.Provider { return forge.New() }, ProviderInfo{
+		DisplayName:       "Forge",
+		Homepage:          "https://minecraftforge.net",
+		HasServerVersions: true,
+	})
+	Register("neoforge", func() provider.Provider { return neoforge.New() }, ProviderInfo{
+		DisplayName:       "NeoForge",
+		Homepage:          "https://neoforged.net",
+		Aliases:           []string{"neo"},
+		HasServerVersions: true,
+	})
+	Register("purpur", func() provider.Provider { return purpur.New() }, ProviderInfo{
+		DisplayName:       "Purpur",
+		Homepage:          "https://purpurmc.org",
+		HasServerVersions: true,
+	})
+	Register("pufferfish", func() provider.Provider { return jenkins.New(jenkins.Pufferfish) }, ProviderInfo{
+		DisplayName:       "Pufferfish",
+		Homepage:          "https://pufferfish.host",
+		HasServerVersions: true,
+	})
+	Register("leaf", func() provider.Provider { return githubrelease.New(githubrelease.Leaf) }, ProviderInfo{
+		DisplayName:       "Leaf",
+		Homepage:          "https://www.leafmc.one",
+		HasServerVersions: true,
+	})
+	Register("canvas", func() provider.Provider { return githubrelease.New(githubrelease.Canvas) }, ProviderInfo{
+		DisplayName:       "Canvas",
+		Homepage:          "https://github.com/CraftCanvasMC/Canvas",
+		HasServerVersions: true,
+	})
+	Register("bedrock", func() provider.Provider { return bedrock.New() }, ProviderInfo{
+		DisplayName: "Bedrock Dedicated Server",
+		Homepage:    "https://www.minecraft.net/download/server/bedrock",
+		Aliases:     []string{"bds"},
+		Edition:     BedrockEdition,
+	})
+}
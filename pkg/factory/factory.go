@@ -2,31 +2,158 @@ package factory
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/fabric"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/forge"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/neoforge"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/paper"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/purpur"
-	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
 )
 
+// Constructor creates a new, independent instance of a provider.
+type Constructor func() provider.Provider
+
+// Edition is the Minecraft edition of a server type.
+type Edition int
+
+const (
+	// JavaEdition servers run on Java and use eula.txt, server.properties and the player list files.
+	JavaEdition Edition = iota
+
+	// BedrockEdition servers are native executables.
+	BedrockEdition
+)
+
+// ProviderInfo describes a registered server type.
+type ProviderInfo struct {
+	// Name is the canonical server type name (e.g., "neoforge"). It is set by Register.
+	Name string
+
+	// DisplayName is the human-readable name of the server type (e.g., "NeoForge").
+	DisplayName string
+
+	// Homepage is the URL of the project's website.
+	Homepage string
+
+	// Aliases lists alternative names that resolve to this server type (e.g., "neo").
+	Aliases []string
+
+	// HasServerVersions reports whether the server type has builds or loader versions
+	// in addition to the game version. It is false for types such as Vanilla.
+	HasServerVersions bool
+
+	// Edition is the Minecraft edition of the servers. It defaults to JavaEdition.
+	Edition Edition
+}
+
+type registration struct {
+	constructor Constructor
+	info        ProviderInfo
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registration{}
+	aliases    = map[string]string{}
+)
+
+// Register makes a server type available under the given name and the aliases listed in info.
+// Names and aliases are case-insensitive.
+// It panics if the name or one of the aliases is already registered, or if constructor is nil.
+func Register(name string, constructor Constructor, info ProviderInfo) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name = strings.ToLower(name)
+	info.Aliases = slices.Clone(info.Aliases)
+	for i, alias := range info.Aliases {
+		info.Aliases[i] = strings.ToLower(alias)
+	}
+
+	if constructor == nil {
+		panic("factory: Register constructor is nil for server type " + name)
+	}
+	if _, exist := resolveLocked(name); exist {
+		panic("factory: Register called twice for server type " + name)
+	}
+	for _, alias := range info.Aliases {
+		if _, exist := resolveLocked(alias); exist || alias == name {
+			panic("factory: Register alias " + alias + " is already in use")
+		}
+	}
+
+	info.Name = name
+	registry[name] = registration{constructor: constructor, info: info}
+	for _, alias := range info.Aliases {
+		aliases[alias] = name
+	}
+}
+
+// resolveLocked returns the canonical name for a server type name or alias.
+// The caller must hold registryMu.
+func resolveLocked(name string) (string, bool) {
+	if _, exist := registry[name]; exist {
+		return name, true
+	}
+	if canonical, exist := aliases[name]; exist {
+		return canonical, true
+	}
+	return "", false
+}
+
+// New creates a provider for the given server type name or alias.
 func New(serverType string) (provider.Provider, error) {
-	switch serverType {
-	case "vanilla":
-		return vanilla.New(), nil
-	case "paper":
-		return paper.New(), nil
-	case "fabric":
-		return fabric.New(), nil
-	case "forge":
-		return forge.New(), nil
-	case "neoforge":
-		return neoforge.New(), nil
-	case "purpur":
-		return purpur.New(), nil
-	default:
+	registryMu.RLock()
+	canonical, ok := resolveLocked(strings.ToLower(serverType))
+	entry := registry[canonical]
+	registryMu.RUnlock()
+
+	if !ok {
 		return nil, fmt.Errorf("unknown server type '%s'", serverType)
 	}
+
+	return entry.constructor(), nil
+}
+
+// Lookup returns the information about the given server type name or alias.
+func Lookup(serverType string) (ProviderInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	canonical, ok := resolveLocked(strings.ToLower(serverType))
+	if !ok {
+		return ProviderInfo{}, false
+	}
+
+	info := registry[canonical].info
+	info.Aliases = slices.Clone(info.Aliases)
+	return info, true
+}
+
+// List returns the information about all registered server types, sorted by name.
+func List() []ProviderInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	infos := make([]ProviderInfo, 0, len(registry))
+	for _, entry := range registry {
+		info := entry.info
+		info.Aliases = slices.Clone(info.Aliases)
+		infos = append(infos, info)
+	}
+
+	slices.SortFunc(infos, func(a, b ProviderInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos
+}
+
+// Names returns the names of all registered server types, sorted alphabetically.
+func Names() []string {
+	infos := List()
+
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	return names
 }
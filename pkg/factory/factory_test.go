@@ -0,0 +1,82 @@
+package factory_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/factory"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/paper"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("built-in type", func(t *testing.T) {
+		if _, err := factory.New("paper"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		if _, err := factory.New("neo"); err != nil {
+			t.Fatalf("expected no error for alias, got: %v", err)
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		if _, err := factory.New("unknown"); err == nil {
+			t.Fatal("expected error for unknown type, got nil")
+		}
+	})
+
+	t.Run("editions", func(t *testing.T) {
+		for name, want := range map[string]factory.Edition{"paper": factory.JavaEdition, "pufferfish": factory.JavaEdition, "bedrock": factory.BedrockEdition} {
+			if info, _ := factory.Lookup(name); info.Edition != want {
+				t.Errorf("expected %s to have edition %d, got %d", name, want, info.Edition)
+			}
+		}
+	})
+}
+
+func TestRegister(t *testing.T) {
+	factory.Register("testfork", func() provider.Provider { return paper.New() }, factory.ProviderInfo{
+		DisplayName:       "Test Fork",
+		Aliases:           []string{"TF"},
+		HasServerVersions: true,
+	})
+
+	t.Run("lookup by alias", func(t *testing.T) {
+		info, ok := factory.Lookup("tf")
+		if !ok {
+			t.Fatal("expected alias to be registered")
+		}
+		if info.Name != "testfork" || info.DisplayName != "Test Fork" || !info.HasServerVersions {
+			t.Errorf("unexpected info: %+v", info)
+		}
+	})
+
+	t.Run("listed", func(t *testing.T) {
+		if !slices.Contains(factory.Names(), "testfork") {
+			t.Error("expected registered type to be listed")
+		}
+	})
+
+	t.Run("duplicate panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for duplicate registration")
+			}
+		}()
+		factory.Register("paper", func() provider.Provider { return paper.New() }, factory.ProviderInfo{})
+	})
+
+	t.Run("alias conflict panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for alias conflict")
+			}
+		}()
+		factory.Register("otherfork", func() provider.Provider { return paper.New() }, factory.ProviderInfo{
+			Aliases: []string{"neo"},
+		})
+	})
+}
@@ -0,0 +1,142 @@
+// Package lockfile records what an install put into a server directory, so that the install can be
+// reproduced elsewhere and checked for changes later.
+package lockfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// FileName is the name of the lock file in a server directory.
+const FileName = "mcserverdl.lock.json"
+
+// SchemaVersion is the version of the lock file format written by this package.
+const SchemaVersion = 1
+
+// Lock describes an install: what was requested from which provider, and the files it produced.
+type Lock struct {
+	// SchemaVersion is the version of the lock file format.
+	SchemaVersion int `json:"schemaVersion"`
+
+	// ToolVersion is the version of mcserverdl that performed the install (e.g., "v2.2.2").
+	ToolVersion string `json:"toolVersion"`
+
+	// Provider is the canonical server type name (e.g., "paper").
+	Provider string `json:"provider"`
+
+	// GameVersion is the Minecraft version (e.g., "1.21.4").
+	GameVersion string `json:"gameVersion"`
+
+	// ServerVersion is the loader version or build. It is empty for types without server versions.
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// Options holds the provider options that change what is installed.
+	Options Options `json:"options"`
+
+	// URL is the download URL of the server jar or archive, if the provider reported one.
+	URL string `json:"url,omitempty"`
+
+	// InstalledAt is the time the install finished.
+	InstalledAt time.Time `json:"installedAt"`
+
+	// Artifacts are the files created or changed by the install.
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Options are the provider options of an install.
+type Options struct {
+	Variant          string `json:"variant,omitempty"`
+	InstallerVersion string `json:"installerVersion,omitempty"`
+	ExtractBundler   bool   `json:"extractBundler,omitempty"`
+	PatchPaperclip   bool   `json:"patchPaperclip,omitempty"`
+	NativeInstall    bool   `json:"nativeInstall,omitempty"`
+	RunInstaller     bool   `json:"runInstaller,omitempty"`
+
+	// JavaSource and JavaVersion describe the Java runtime provisioned into RuntimeDir, if any
+	// (e.g., "adoptium" and 21). The runtime files are not artifacts, since upstream replaces their builds.
+	JavaSource  string `json:"javaSource,omitempty"`
+	JavaVersion int    `json:"javaVersion,omitempty"`
+}
+
+// RuntimeDir is the directory of a server directory that a provisioned Java runtime is installed into.
+const RuntimeDir = "runtime"
+
+// Artifact is a file of an install.
+type Artifact struct {
+	// Path is the path of the file relative to the server directory, with forward slashes.
+	Path string `json:"path"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// SHA256 is the hex-encoded SHA-256 digest of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// Read reads a lock file.
+func Read(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if lock.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("%s has schema version %d, but only versions up to %d are supported", path, lock.SchemaVersion, SchemaVersion)
+	}
+	if lock.Provider == "" || lock.GameVersion == "" {
+		return nil, fmt.Errorf("%s does not record a provider and game version", path)
+	}
+	return &lock, nil
+}
+
+// Write writes the lock file to path.
+func (l *Lock) Write(path string) error {
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(l); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content.Bytes(), 0644)
+}
+
+// HashArtifacts computes the artifacts of files in a server directory.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - paths: the paths of the files relative to dir, with forward slashes.
+//
+// Returns:
+//   - []Artifact: the artifacts, sorted by path.
+//   - error: an error if a file cannot be read.
+func HashArtifacts(dir string, paths []string) ([]Artifact, error) {
+	artifacts := make([]Artifact, 0, len(paths))
+	for _, path := range paths {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := internal.HashFile(fullPath, "sha256")
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, Artifact{Path: path, Size: info.Size(), SHA256: digest})
+	}
+
+	slices.SortFunc(artifacts, func(a, b Artifact) int { return strings.Compare(a.Path, b.Path) })
+	return artifacts, nil
+}
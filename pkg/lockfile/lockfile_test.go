@@ -0,0 +1,94 @@
+package lockfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "server.properties"), "motd=Hello\n")
+	writeFile(t, filepath.Join(dir, "server.jar"), "old")
+
+	before, err := lockfile.TakeSnapshot(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	writeFile(t, filepath.Join(dir, "server.jar"), "new jar")
+	writeFile(t, filepath.Join(dir, "libraries", "lib.jar"), "library")
+
+	after, err := lockfile.TakeSnapshot(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := before.Changed(after)
+	slices.Sort(changed)
+	if !reflect.DeepEqual(changed, []string{"libraries/lib.jar", "server.jar"}) {
+		t.Errorf("unexpected changed files %v", changed)
+	}
+
+	// A directory that doesn't exist yet has no files
+	missing, err := lockfile.TakeSnapshot(filepath.Join(dir, "missing"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("expected an empty snapshot, got %v (%v)", missing, err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "server.jar"), "server")
+	writeFile(t, filepath.Join(dir, "libraries", "lib.jar"), "library")
+
+	artifacts, err := lockfile.HashArtifacts(dir, []string{"server.jar", "libraries/lib.jar"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	expected := []lockfile.Artifact{
+		{Path: "libraries/lib.jar", Size: 7, SHA256: "b718f1354f7247312eca086d9a024afe5fa717ddea5adeddd6f12bcf945b2e8c"},
+		{Path: "server.jar", Size: 6, SHA256: "b3eacd33433b31b5252351032c9b3e7a2e7aa7738d5decdf0dd6c62680853c06"},
+	}
+	if !reflect.DeepEqual(artifacts, expected) {
+		t.Errorf("unexpected artifacts %+v", artifacts)
+	}
+
+	lock := &lockfile.Lock{
+		SchemaVersion: lockfile.SchemaVersion,
+		ToolVersion:   "v2.2.2",
+		Provider:      "paper",
+		GameVersion:   "1.21.4",
+		ServerVersion: "232",
+		Options:       lockfile.Options{Variant: "mojmap", JavaSource: "adoptium", JavaVersion: 21},
+		URL:           "https://example.com/paper.jar",
+		InstalledAt:   time.Date(2024, time.October, 15, 12, 30, 0, 0, time.UTC),
+		Artifacts:     artifacts,
+	}
+	lockPath := filepath.Join(dir, lockfile.FileName)
+	if err := lock.Write(lockPath); err != nil {
+		t.Fatal(err)
+	}
+	read, err := lockfile.Read(lockPath)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(read, lock) {
+		t.Errorf("expected the lock to read back unchanged, got %+v", read)
+	}
+
+}
@@ -0,0 +1,58 @@
+package lockfile
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshot records the size and modification time of the files in a directory,
+// to find the files an install creates or changes.
+type Snapshot map[string]fileState
+
+// fileState is the state of a file in a snapshot.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// TakeSnapshot records the regular files in a directory. A missing directory has no files.
+// Paths are relative to dir, with forward slashes.
+func TakeSnapshot(dir string) (Snapshot, error) {
+	snapshot := Snapshot{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(relPath)] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return snapshot, err
+}
+
+// Changed returns the files of a later snapshot of the directory that are new or changed since s.
+func (s Snapshot) Changed(later Snapshot) []string {
+	var paths []string
+	for path, state := range later {
+		if before, ok := s[path]; !ok || before.size != state.size || !before.modTime.Equal(state.modTime) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,14 @@
+package bedrock
+
+import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+
+type Provider struct {
+	provider.BaseProvider
+
+	// linksURL is the endpoint listing the current server downloads.
+	linksURL string
+}
+
+func New() *Provider {
+	return &Provider{linksURL: downloadLinksURL}
+}
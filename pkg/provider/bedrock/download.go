@@ -0,0 +1,77 @@
+package bedrock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// preservedFiles lists the configuration files that are kept when updating an existing installation.
+var preservedFiles = []string{"server.properties", "allowlist.json", "permissions.json"}
+
+// Download downloads and extracts the Bedrock Dedicated Server to the specified installation directory.
+// It uses a default background context.
+func (p *Provider) Download(gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
+	return p.DownloadContext(context.Background(), gameVersion, serverVersion, installDir, onProgress)
+}
+
+// DownloadContext downloads and extracts the Bedrock Dedicated Server to the specified installation directory with context support.
+// Existing server.properties, allowlist.json and permissions.json files are preserved so that an
+// existing installation can be updated in place.
+//
+// Parameters:
+//   - ctx: the context to control the download cancellation.
+//   - gameVersion: the Bedrock version string (e.g., "1.21.50.07").
+//   - serverVersion: ignored for Bedrock.
+//   - installDir: the directory where the server will be extracted.
+//   - onProgress: a callback function to report download progress.
+//
+// Returns:
+//   - error: an error if the download or extraction fails.
+func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
+	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return err
+	}
+
+	archivePath := filepath.Join(installDir, "bedrock-server.zip")
+
+	// Clean up the archive once it has been extracted
+	defer os.Remove(archivePath)
+
+	p.Log("Downloading server...")
+	if err := internal.Download(ctx, url, archivePath, onProgress); err != nil {
+		return err
+	}
+	p.Log("Download complete!")
+
+	p.Log("Extracting server...")
+	err = internal.ExtractZip(ctx, archivePath, installDir, func(name string) bool {
+		if !slices.Contains(preservedFiles, name) {
+			return false
+		}
+
+		// Keep the configuration file only if the installation already has one
+		_, statErr := os.Stat(filepath.Join(installDir, name))
+		if statErr == nil {
+			p.Log("Keeping existing %s", name)
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	// The archive does not always record permission bits, so mark the server binary as executable
+	if err := os.Chmod(filepath.Join(installDir, "bedrock_server"), 0755); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	p.Log("Successfully extracted server to %s", installDir)
+
+	return nil
+}
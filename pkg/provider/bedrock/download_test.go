@@ -0,0 +1,99 @@
+package bedrock
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownload(t *testing.T) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"bedrock_server":                       "new server",
+		"server.properties":                    "server-name=Dedicated Server\n",
+		"allowlist.json":                       "[]",
+		"permissions.json":                     "[]",
+		"behavior_packs/vanilla/manifest.json": "{}",
+	} {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/links":
+			fmt.Fprintf(w, `{"result":{"links":[{"downloadType":"serverBedrockLinux","downloadUrl":"%s/bin-linux/bedrock-server-1.21.50.07.zip"}]}}`, server.URL)
+		case "/bin-linux/bedrock-server-1.21.50.07.zip":
+			w.Write(archive.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Provider{linksURL: server.URL + "/links"}
+
+	t.Run("fresh install", func(t *testing.T) {
+		installDir := t.TempDir()
+		if err := p.Download("1.21.50.07", "", installDir, nil); err != nil {
+			t.Fatalf("download failed: %v", err)
+		}
+
+		for name, want := range map[string]string{"bedrock_server": "new server", "server.properties": "server-name=Dedicated Server\n", "allowlist.json": "[]"} {
+			if content, err := os.ReadFile(filepath.Join(installDir, name)); err != nil || string(content) != want {
+				t.Errorf("unexpected %s %q: %v", name, content, err)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(installDir, "bedrock-server.zip")); !os.IsNotExist(err) {
+			t.Error("expected the archive to be removed")
+		}
+	})
+
+	t.Run("update keeps the configuration", func(t *testing.T) {
+		installDir := t.TempDir()
+		existing := map[string]string{
+			"bedrock_server":    "old server",
+			"server.properties": "server-name=My Server\n",
+			"allowlist.json":    `[{"name":"Steve"}]`,
+			"permissions.json":  `[{"permission":"operator","xuid":"1"}]`,
+		}
+		for name, content := range existing {
+			if err := os.WriteFile(filepath.Join(installDir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := p.Download("1.21.50.07", "", installDir, nil); err != nil {
+			t.Fatalf("download failed: %v", err)
+		}
+
+		existing["bedrock_server"] = "new server"
+		existing["behavior_packs/vanilla/manifest.json"] = "{}"
+		for name, want := range existing {
+			if content, err := os.ReadFile(filepath.Join(installDir, filepath.FromSlash(name))); err != nil || string(content) != want {
+				t.Errorf("unexpected %s %q: %v", name, content, err)
+			}
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		if err := p.Download("1.0.0.0", "", t.TempDir(), nil); err == nil {
+			t.Error("expected error for an unsupported version, got nil")
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package bedrock
+
+import (
+	"context"
+	"fmt"
+)
+
+// DownloadURL returns the download URL for the Linux Bedrock Dedicated Server archive for a given game version.
+// It uses a default background context.
+func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error) {
+	return p.DownloadURLContext(context.Background(), gameVersion, serverVersion)
+}
+
+// DownloadURLContext returns the download URL for the Linux Bedrock Dedicated Server archive for a given game version with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Bedrock version string (e.g., "1.21.50.07").
+//   - serverVersion: ignored for Bedrock as it doesn't have separate server versions.
+//
+// Returns:
+//   - string: the direct download URL for the server zip archive.
+//   - error: an error if the version is not found or if any HTTP or JSON decoding issues occur.
+func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
+	p.Log("Fetching download URL for Bedrock %s...", gameVersion)
+
+	_, urls, err := p.fetchLinuxDownloads(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	serverURL, ok := urls[gameVersion]
+	if !ok {
+		return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+	}
+
+	p.Log("Fetched Bedrock download URL: %s", serverURL)
+	return serverURL, nil
+}
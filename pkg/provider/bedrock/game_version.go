@@ -0,0 +1,87 @@
+package bedrock
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// downloadLinksURL is the official endpoint listing the current Bedrock Dedicated Server downloads.
+const downloadLinksURL = "https://net-secondary.web.minecraft-services.net/api/v1.0/download/links"
+
+// linuxDownloadTypes lists the Linux server download types, release builds first.
+var linuxDownloadTypes = []string{"serverBedrockLinux", "serverBedrockPreviewLinux"}
+
+// serverArchivePattern extracts the game version from a server archive file name
+// (e.g., "bedrock-server-1.21.50.07.zip" -> "1.21.50.07").
+var serverArchivePattern = regexp.MustCompile(`bedrock-server-([0-9.]+)\.zip$`)
+
+type downloadLinksManifest struct {
+	Result struct {
+		Links []struct {
+			DownloadType string `json:"downloadType"`
+			DownloadURL  string `json:"downloadUrl"`
+		} `json:"links"`
+	} `json:"result"`
+}
+
+// fetchLinuxDownloads fetches the download links manifest and returns the Linux server
+// archive URLs keyed by game version, along with the game versions in display order.
+func (p *Provider) fetchLinuxDownloads(ctx context.Context) ([]string, map[string]string, error) {
+	var linkData downloadLinksManifest
+	if err := internal.FetchJSON(ctx, p.linksURL, &linkData); err != nil {
+		return nil, nil, err
+	}
+
+	versions := make([]string, 0, len(linuxDownloadTypes))
+	urls := make(map[string]string, len(linuxDownloadTypes))
+	for _, downloadType := range linuxDownloadTypes {
+		for _, link := range linkData.Result.Links {
+			if !strings.EqualFold(link.DownloadType, downloadType) {
+				continue
+			}
+
+			match := serverArchivePattern.FindStringSubmatch(link.DownloadURL)
+			if match == nil {
+				continue
+			}
+
+			if _, exist := urls[match[1]]; !exist {
+				versions = append(versions, match[1])
+				urls[match[1]] = link.DownloadURL
+			}
+		}
+	}
+
+	return versions, urls, nil
+}
+
+// GameVersions fetches the list of Bedrock Dedicated Server versions from the official download links endpoint.
+// It uses a default background context.
+func (p *Provider) GameVersions() ([]string, error) {
+	return p.GameVersionsContext(context.Background())
+}
+
+// GameVersionsContext fetches the list of Bedrock Dedicated Server versions from the official download links endpoint with context support.
+// The endpoint only publishes the current release and preview builds.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//
+// Returns:
+//   - []string: a slice of Bedrock versions (e.g., "1.21.50.07"), release first.
+//   - error: an error if any HTTP or JSON decoding issues occur.
+func (p *Provider) GameVersionsContext(ctx context.Context) ([]string, error) {
+	p.Log("Fetching supported Bedrock game versions...")
+
+	versions, _, err := p.fetchLinuxDownloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Log("Fetched %d Bedrock game versions", len(versions))
+
+	return versions, nil
+}
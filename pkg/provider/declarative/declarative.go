@@ -0,0 +1,54 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+type Provider struct {
+	provider.BaseProvider
+
+	definition Definition
+}
+
+// New returns a provider for the given definition.
+// The definition is expected to be valid; see Definition.Validate.
+func New(definition Definition) *Provider {
+	if definition.DisplayName == "" {
+		definition.DisplayName = definition.Name
+	}
+
+	return &Provider{definition: definition}
+}
+
+// expandURL replaces the "{game}" and "{server}" placeholders of a URL template.
+func expandURL(template, gameVersion, serverVersion string) string {
+	return strings.NewReplacer(
+		"{game}", url.PathEscape(gameVersion),
+		"{server}", url.PathEscape(serverVersion),
+	).Replace(template)
+}
+
+// fetchSelect fetches the endpoint and returns the values matched by its selector.
+func fetchSelect(ctx context.Context, endpoint Endpoint, gameVersion, serverVersion string) ([]string, error) {
+	var document json.RawMessage
+	if err := internal.FetchJSON(ctx, expandURL(endpoint.URL, gameVersion, serverVersion), &document); err != nil {
+		return nil, err
+	}
+
+	values, err := internal.SelectJSON(document, endpoint.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Reverse {
+		slices.Reverse(values)
+	}
+	return values, nil
+}
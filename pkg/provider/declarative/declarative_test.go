@@ -0,0 +1,187 @@
+package declarative_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/declarative"
+)
+
+const yamlDefinition = `name: testfork
+displayName: Test Fork
+aliases: [tf]
+gameVersions:
+  url: "{base}/v2/testfork"
+  selector: $.versions[*]
+  reverse: true
+serverVersions:
+  url: "{base}/v2/testfork/{game}"
+  selector: $.builds.all[*]
+  reverse: true
+download:
+  url: "{base}/v2/testfork/{game}/{server}/download"
+`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/testfork":
+			fmt.Fprint(w, `{"versions":["1.20.6","1.21.4"]}`)
+		case "/v2/testfork/1.21.4":
+			fmt.Fprint(w, `{"builds":{"all":["1","2"]}}`)
+		case "/v2/testfork/1.20.6":
+			fmt.Fprint(w, `{"builds":{"all":[]}}`)
+		case "/v2/testfork/1.19.4":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case "/v2/testfork/1.21.4/2/download":
+			fmt.Fprint(w, "jar")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func writeDefinition(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write definition: %v", err)
+	}
+	return path
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeDefinition(t, dir, "a.yaml", yamlDefinition)
+	writeDefinition(t, dir, "b.json", `{"name":"jsonfork","gameVersions":{"url":"https://example.com","selector":"$[*]"},"download":{"url":"https://example.com/{game}.jar"}}`)
+	writeDefinition(t, dir, "README.md", "ignored")
+
+	definitions, err := declarative.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(definitions) != 2 || definitions[0].Name != "testfork" || definitions[1].Name != "jsonfork" {
+		t.Fatalf("unexpected definitions: %+v", definitions)
+	}
+	if definitions[1].ServerVersions != nil {
+		t.Error("expected JSON definition without server versions")
+	}
+
+	t.Run("missing directory", func(t *testing.T) {
+		definitions, err := declarative.LoadDir(filepath.Join(dir, "missing"))
+		if err != nil || len(definitions) != 0 {
+			t.Errorf("expected no definitions and no error, got %v, %v", definitions, err)
+		}
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		path := writeDefinition(t, t.TempDir(), "bad.yaml", "name: bad\n")
+		if _, err := declarative.LoadFile(path); err == nil {
+			t.Error("expected validation error, got nil")
+		}
+	})
+
+	t.Run("java version", func(t *testing.T) {
+		definition, err := declarative.LoadFile(writeDefinition(t, t.TempDir(), "java.yaml", yamlDefinition+"javaVersion: 21\n"))
+		if err != nil || definition.JavaVersion != 21 {
+			t.Errorf("expected javaVersion 21, got %d: %v", definition.JavaVersion, err)
+		}
+		if _, err := declarative.LoadFile(writeDefinition(t, t.TempDir(), "bad-java.yaml", yamlDefinition+"javaVersion: -1\n")); err == nil {
+			t.Error("expected validation error for a negative javaVersion, got nil")
+		}
+	})
+
+	t.Run("invalid aliases", func(t *testing.T) {
+		for _, aliases := range []string{"['']", "[TestFork]", "[tf2, TF2]"} {
+			content := strings.Replace(yamlDefinition, "aliases: [tf]", "aliases: "+aliases, 1)
+			path := writeDefinition(t, t.TempDir(), "aliases.yaml", content)
+			if _, err := declarative.LoadFile(path); err == nil {
+				t.Errorf("expected validation error for aliases %s, got nil", aliases)
+			}
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := writeDefinition(t, t.TempDir(), "typo.yaml", yamlDefinition+"downlaod: {}\n")
+		if _, err := declarative.LoadFile(path); err == nil {
+			t.Error("expected error for unknown field, got nil")
+		}
+	})
+}
+
+func TestProvider(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	dir := t.TempDir()
+	content := strings.ReplaceAll(yamlDefinition, "{base}", server.URL)
+	definition, err := declarative.LoadFile(writeDefinition(t, dir, "testfork.yml", content))
+	if err != nil {
+		t.Fatalf("failed to load definition: %v", err)
+	}
+	p := declarative.New(definition)
+
+	// Custom server types are Java edition servers
+	if _, ok := any(p).(provider.JavaVersionRequirer); !ok {
+		t.Error("expected the provider to report its required Java version")
+	}
+
+	t.Run("game versions", func(t *testing.T) {
+		versions, err := p.GameVersions()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(versions, []string{"1.21.4", "1.20.6"}) {
+			t.Errorf("unexpected game versions: %v", versions)
+		}
+	})
+
+	t.Run("server versions", func(t *testing.T) {
+		builds, err := p.ServerVersions("1.21.4")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(builds, []string{"2", "1"}) {
+			t.Errorf("unexpected builds: %v", builds)
+		}
+
+		if _, err := p.ServerVersions("invalid version"); err == nil {
+			t.Error("expected error for invalid game version, got nil")
+		}
+	})
+
+	t.Run("server versions errors", func(t *testing.T) {
+		_, err := p.ServerVersions("1.20.6")
+		if err == nil || !strings.Contains(err.Error(), "unsupported game version") {
+			t.Errorf("expected unsupported game version for an empty build list, got: %v", err)
+		}
+
+		_, err = p.ServerVersions("1.19.4")
+		if err == nil || strings.Contains(err.Error(), "unsupported game version") || !strings.Contains(err.Error(), "503") {
+			t.Errorf("expected the fetch error to be reported, got: %v", err)
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		if _, err := p.DownloadURL("1.21.4", "3"); err == nil {
+			t.Error("expected error for unknown build, got nil")
+		}
+
+		installDir := t.TempDir()
+		if err := p.Download("1.21.4", "2", installDir, nil); err != nil {
+			t.Fatalf("download failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(installDir, "server.jar"))
+		if err != nil || string(content) != "jar" {
+			t.Errorf("unexpected server jar content %q: %v", content, err)
+		}
+	})
+}
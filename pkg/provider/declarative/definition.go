@@ -0,0 +1,161 @@
+package declarative
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Endpoint describes an HTTP JSON endpoint and how to select values from its response.
+type Endpoint struct {
+	// URL is the endpoint URL. The placeholders "{game}" and "{server}" are replaced
+	// with the path-escaped game version and server version.
+	URL string `json:"url" yaml:"url"`
+
+	// Selector is a JSONPath-like selector into the response (e.g., "$.builds.all[*]").
+	// For the download endpoint it is optional: without a selector, URL is the download URL itself.
+	Selector string `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	// Reverse reverses the selected values, for APIs that list older versions first.
+	Reverse bool `json:"reverse,omitempty" yaml:"reverse,omitempty"`
+}
+
+// Definition describes a server type whose versions and downloads are served by HTTP JSON APIs.
+type Definition struct {
+	// Name is the server type name used with the factory (e.g., "myfork").
+	Name string `json:"name" yaml:"name"`
+
+	// DisplayName is the human-readable name used in log messages. Defaults to Name.
+	DisplayName string `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+
+	// Homepage is the URL of the project's website.
+	Homepage string `json:"homepage,omitempty" yaml:"homepage,omitempty"`
+
+	// Aliases lists alternative names that resolve to this server type.
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+
+	// GameVersions lists the supported game versions.
+	GameVersions Endpoint `json:"gameVersions" yaml:"gameVersions"`
+
+	// ServerVersions lists the builds of a game version. It may be omitted for
+	// server types that only have a single build per game version.
+	ServerVersions *Endpoint `json:"serverVersions,omitempty" yaml:"serverVersions,omitempty"`
+
+	// Download resolves the download URL of the server jar.
+	Download Endpoint `json:"download" yaml:"download"`
+
+	// JavaVersion is the minimum Java major version the server needs (e.g., 21). It raises the requirement
+	// of the vanilla server of the game version and may be omitted for forks that need no newer Java.
+	JavaVersion int `json:"javaVersion,omitempty" yaml:"javaVersion,omitempty"`
+}
+
+// Validate checks that the definition has every required field.
+func (d Definition) Validate() error {
+	if d.Name == "" {
+		return errors.New("provider definition is missing a name")
+	}
+	if strings.ContainsAny(d.Name, " \t/") {
+		return fmt.Errorf("provider name %q must not contain spaces or slashes", d.Name)
+	}
+	names := map[string]struct{}{strings.ToLower(d.Name): {}}
+	for _, alias := range d.Aliases {
+		if alias == "" || strings.ContainsAny(alias, " \t/") {
+			return fmt.Errorf("provider %s: invalid alias %q", d.Name, alias)
+		}
+		// Names and aliases are case-insensitive in the factory registry
+		if _, exist := names[strings.ToLower(alias)]; exist {
+			return fmt.Errorf("provider %s: alias %q repeats the name or another alias", d.Name, alias)
+		}
+		names[strings.ToLower(alias)] = struct{}{}
+	}
+	if d.GameVersions.URL == "" || d.GameVersions.Selector == "" {
+		return fmt.Errorf("provider %s: gameVersions requires a url and a selector", d.Name)
+	}
+	if d.ServerVersions != nil && (d.ServerVersions.URL == "" || d.ServerVersions.Selector == "") {
+		return fmt.Errorf("provider %s: serverVersions requires a url and a selector", d.Name)
+	}
+	if d.Download.URL == "" {
+		return fmt.Errorf("provider %s: download requires a url", d.Name)
+	}
+	if d.JavaVersion < 0 {
+		return fmt.Errorf("provider %s: invalid javaVersion %d", d.Name, d.JavaVersion)
+	}
+	return nil
+}
+
+// DefaultDir returns the default directory of provider definition files
+// (e.g., "~/.config/mcserverdl/providers.d" on Linux).
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "mcserverdl", "providers.d"), nil
+}
+
+// LoadFile reads and validates a single provider definition from a YAML or JSON file.
+// The format is chosen by the file extension (".json", ".yaml" or ".yml").
+func LoadFile(path string) (Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Definition{}, err
+	}
+
+	var definition Definition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&definition)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&definition)
+	default:
+		return Definition{}, fmt.Errorf("unsupported provider definition format: %s", path)
+	}
+	if err != nil {
+		return Definition{}, fmt.Errorf("failed to decode provider definition %s: %w", path, err)
+	}
+
+	if err := definition.Validate(); err != nil {
+		return Definition{}, fmt.Errorf("invalid provider definition %s: %w", path, err)
+	}
+
+	return definition, nil
+}
+
+// LoadDir reads every provider definition file (".json", ".yaml" or ".yml") in a directory, sorted by file name.
+// A missing directory is not an error and yields no definitions.
+func LoadDir(dir string) ([]Definition, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []Definition
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !slices.Contains([]string{".json", ".yaml", ".yml"}, extension) {
+			continue
+		}
+
+		definition, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
@@ -0,0 +1,65 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// DownloadURL returns the download URL for the server jar for a given game version and build.
+// It uses a default background context.
+func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error) {
+	return p.DownloadURLContext(context.Background(), gameVersion, serverVersion)
+}
+
+// DownloadURLContext returns the download URL for the server jar for a given game version and build with context support.
+// The build is validated against the server versions endpoint when the definition has one.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string.
+//   - serverVersion: the build for the specified version; ignored if the definition has no server versions.
+//
+// Returns:
+//   - string: the direct download URL for the server JAR file.
+//   - error: an error if the game version or build is not found, or if any HTTP, JSON decoding or selector issues occur.
+func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
+	p.Log("Fetching download URL for %s %s build %s...", p.definition.DisplayName, gameVersion, serverVersion)
+
+	if p.definition.ServerVersions != nil {
+		builds, err := p.ServerVersionsContext(ctx, gameVersion)
+		if err != nil {
+			return "", err
+		}
+		if !slices.Contains(builds, serverVersion) {
+			return "", fmt.Errorf("build %s not found for version %s", serverVersion, gameVersion)
+		}
+	} else {
+		versions, err := p.GameVersionsContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !slices.Contains(versions, gameVersion) {
+			return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+		}
+	}
+
+	// Without a selector, the download endpoint is the download URL itself
+	if p.definition.Download.Selector == "" {
+		serverURL := expandURL(p.definition.Download.URL, gameVersion, serverVersion)
+		p.Log("Fetched %s download URL: %s", p.definition.DisplayName, serverURL)
+		return serverURL, nil
+	}
+
+	urls, err := fetchSelect(ctx, p.definition.Download, gameVersion, serverVersion)
+	if err != nil {
+		return "", err
+	}
+	if len(urls) == 0 {
+		return "", fmt.Errorf("no download URL found for %s %s build %s", p.definition.DisplayName, gameVersion, serverVersion)
+	}
+
+	serverURL := urls[0]
+	p.Log("Fetched %s download URL: %s", p.definition.DisplayName, serverURL)
+	return serverURL, nil
+}
@@ -0,0 +1,30 @@
+package declarative
+
+import "context"
+
+// GameVersions fetches the list of supported game versions from the definition's game versions endpoint.
+// It uses a default background context.
+func (p *Provider) GameVersions() ([]string, error) {
+	return p.GameVersionsContext(context.Background())
+}
+
+// GameVersionsContext fetches the list of supported game versions from the definition's game versions endpoint with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//
+// Returns:
+//   - []string: a slice of Minecraft versions selected from the response.
+//   - error: an error if any HTTP, JSON decoding or selector issues occur.
+func (p *Provider) GameVersionsContext(ctx context.Context) ([]string, error) {
+	p.Log("Fetching supported %s game versions...", p.definition.DisplayName)
+
+	versions, err := fetchSelect(ctx, p.definition.GameVersions, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	p.Log("Fetched %d %s game versions", len(versions), p.definition.DisplayName)
+
+	return versions, nil
+}
@@ -0,0 +1,33 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
+
+// RequiredJavaVersion returns the minimum Java major version required to run the server.
+// It uses a default background context.
+func (p *Provider) RequiredJavaVersion(gameVersion, serverVersion string) (int, error) {
+	return p.RequiredJavaVersionContext(context.Background(), gameVersion, serverVersion)
+}
+
+// RequiredJavaVersionContext returns the minimum Java major version required to run the server with context support.
+// The requirement from the Mojang version details is raised to the definition's javaVersion, if any.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.4", "1.20.6").
+//   - serverVersion: ignored, every build of a game version has the same requirement.
+//
+// Returns:
+//   - int: the required Java major version (e.g., 8, 17, 21).
+//   - error: an error if the game version is not found or if any HTTP or JSON decoding issues occur.
+func (p *Provider) RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error) {
+	required, err := vanilla.JavaBaseline{}.RequiredJavaVersionContext(ctx, gameVersion, "")
+	if err != nil {
+		return 0, err
+	}
+
+	return max(required, p.definition.JavaVersion), nil
+}
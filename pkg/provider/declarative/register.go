@@ -0,0 +1,51 @@
+package declarative
+
+import (
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/factory"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+// Register adds the definition to the factory registry.
+// Unlike factory.Register, it returns an error instead of panicking when the name or an alias is already in use.
+func Register(definition Definition) error {
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+
+	for _, name := range append([]string{definition.Name}, definition.Aliases...) {
+		if _, exist := factory.Lookup(name); exist {
+			return fmt.Errorf("provider %s: server type %q is already registered", definition.Name, name)
+		}
+	}
+
+	displayName := definition.DisplayName
+	if displayName == "" {
+		displayName = definition.Name
+	}
+
+	factory.Register(definition.Name, func() provider.Provider { return New(definition) }, factory.ProviderInfo{
+		DisplayName:       displayName,
+		Homepage:          definition.Homepage,
+		Aliases:           definition.Aliases,
+		HasServerVersions: definition.ServerVersions != nil,
+	})
+	return nil
+}
+
+// RegisterDir loads every provider definition in a directory and adds it to the factory registry.
+// A missing directory is not an error.
+func RegisterDir(dir string) error {
+	definitions, err := LoadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, definition := range definitions {
+		if err := Register(definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
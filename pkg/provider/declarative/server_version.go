@@ -0,0 +1,42 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+)
+
+// ServerVersions fetches the list of builds for a given game version from the definition's server versions endpoint.
+// It uses a default background context.
+func (p *Provider) ServerVersions(gameVersion string) ([]string, error) {
+	return p.ServerVersionsContext(context.Background(), gameVersion)
+}
+
+// ServerVersionsContext fetches the list of builds for a given game version from the definition's server versions endpoint with context support.
+// If the definition has no server versions endpoint, this always returns an error.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string.
+//
+// Returns:
+//   - []string: a slice of builds for the specified game version.
+//   - error: an error if the game version is not supported or if any HTTP, JSON decoding or selector issues occur.
+func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string) ([]string, error) {
+	if p.definition.ServerVersions == nil {
+		p.Log("%s does not support server versions", p.definition.DisplayName)
+		return nil, fmt.Errorf("%s server does not have version", p.definition.Name)
+	}
+
+	p.Log("Fetching %s server versions (builds) for %s...", p.definition.DisplayName, gameVersion)
+
+	builds, err := fetchSelect(ctx, *p.definition.ServerVersions, gameVersion, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s server versions for %s: %w", p.definition.DisplayName, gameVersion, err)
+	}
+	if len(builds) == 0 {
+		return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
+	}
+
+	p.Log("Fetched %d %s builds for %s", len(builds), p.definition.DisplayName, gameVersion)
+	return builds, nil
+}
@@ -4,14 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-
-	"github.com/abulleDev/mcserverdl/v2/internal"
+	"slices"
 )
 
-type installerVersionManifest []struct {
-	Version string `json:"version"`
-}
-
 // DownloadURL returns the download URL for the Fabric server JAR for a given game version and loader version.
 // It uses a default background context.
 func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error) {
@@ -19,6 +14,7 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 }
 
 // DownloadURLContext returns the download URL for the Fabric server JAR for a given game version and loader version with context support.
+// The server launcher is built with the pinned installer version (see SetInstallerVersion), or the latest one.
 //
 // Parameters:
 //   - ctx: the context to control the request lifetime.
@@ -27,12 +23,12 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 //
 // Returns:
 //   - string: the direct download URL for the Fabric server JAR file if the versions exist.
-//   - error: an error if the game version or loader version is not found, or if any HTTP or JSON decoding issues occur.
+//   - error: an error if the game version, loader version or installer version is not found, or if any HTTP or JSON decoding issues occur.
 func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion string, serverVersion string) (string, error) {
-	p.Log("Fetching download URL for Fabric %s with loader %s...", gameVersion, serverVersion)
+	p.Log("Fetching download URL for %s %s with loader %s...", p.name, gameVersion, serverVersion)
 
 	// Check Fabric support for the given game version
-	checkGameURL := fmt.Sprintf("https://meta2.fabricmc.net/v2/versions/loader/%s", gameVersion)
+	checkGameURL := fmt.Sprintf("%s/v2/versions/loader/%s", p.metaURL, gameVersion)
 
 	// Create request with context for game version check
 	reqGame, err := http.NewRequestWithContext(ctx, http.MethodGet, checkGameURL, nil)
@@ -56,7 +52,7 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion string, s
 	}
 
 	// Check Fabric support for the given server version
-	checkServerURL := fmt.Sprintf("https://meta2.fabricmc.net/v2/versions/loader/%s/%s", gameVersion, serverVersion)
+	checkServerURL := fmt.Sprintf("%s/v2/versions/loader/%s/%s", p.metaURL, gameVersion, serverVersion)
 
 	// Create request with context for server version check
 	reqServer, err := http.NewRequestWithContext(ctx, http.MethodGet, checkServerURL, nil)
@@ -80,17 +76,25 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion string, s
 	}
 
 	// Fetch all available installer versions
-	const installerURL = "https://meta2.fabricmc.net/v2/versions/installer"
-	var installerData installerVersionManifest
-	if err := internal.FetchJSON(ctx, installerURL, &installerData); err != nil {
+	installerVersions, err := p.InstallerVersionsContext(ctx)
+	if err != nil {
 		return "", err
 	}
+	if len(installerVersions) == 0 {
+		return "", fmt.Errorf("no %s installer versions available", p.name)
+	}
 
-	// Use the latest installer version
-	latestInstallerVersion := installerData[0].Version
+	// Use the pinned installer version, or the latest one
+	installerVersion := installerVersions[0].Version
+	if p.installerVersion != "" {
+		if !slices.ContainsFunc(installerVersions, func(v InstallerVersion) bool { return v.Version == p.installerVersion }) {
+			return "", fmt.Errorf("unsupported installer version: %s", p.installerVersion)
+		}
+		installerVersion = p.installerVersion
+	}
 
 	// Build and return the download URL
-	serverURL := fmt.Sprintf("https://meta.fabricmc.net/v2/versions/loader/%s/%s/%s/server/jar", gameVersion, serverVersion, latestInstallerVersion)
-	p.Log("Fetched Fabric download URL: %s", serverURL)
+	serverURL := fmt.Sprintf("%s/v2/versions/loader/%s/%s/%s/server/jar", p.downloadURL, gameVersion, serverVersion, installerVersion)
+	p.Log("Fetched %s download URL: %s", p.name, serverURL)
 	return serverURL, nil
 }
@@ -2,10 +2,53 @@ package fabric
 
 import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
 
+const (
+	// fabricMetaURL serves the Fabric meta v2 API used to list and validate versions.
+	fabricMetaURL = "https://meta2.fabricmc.net"
+
+	// fabricDownloadURL serves the Fabric meta v2 API used to download the server launcher.
+	fabricDownloadURL = "https://meta.fabricmc.net"
+
+	// legacyFabricMetaURL serves the Legacy Fabric meta v2 API for game versions 1.3 to 1.13.2.
+	legacyFabricMetaURL = "https://meta.legacyfabric.net"
+)
+
 type Provider struct {
 	provider.BaseProvider
+
+	// name is the display name used in log messages.
+	name string
+
+	// metaURL is the base URL of the meta v2 API used to list and validate versions.
+	metaURL string
+
+	// downloadURL is the base URL of the meta v2 API serving the server launcher jar.
+	downloadURL string
+
+	// installerVersion is the pinned installer version; empty means the latest installer.
+	installerVersion string
 }
 
 func New() *Provider {
-	return &Provider{}
+	return &Provider{
+		name:        "Fabric",
+		metaURL:     fabricMetaURL,
+		downloadURL: fabricDownloadURL,
+	}
+}
+
+// NewLegacy returns a provider for Legacy Fabric, which serves the same meta v2 schema
+// as Fabric for the game versions that predate official Fabric support (1.3 to 1.13.2).
+func NewLegacy() *Provider {
+	return &Provider{
+		name:        "Legacy Fabric",
+		metaURL:     legacyFabricMetaURL,
+		downloadURL: legacyFabricMetaURL,
+	}
+}
+
+// SetInstallerVersion pins the installer version used to build the server launcher (e.g., "1.0.1"),
+// which makes the downloaded launcher reproducible. An empty string selects the latest installer.
+func (p *Provider) SetInstallerVersion(version string) {
+	p.installerVersion = version
 }
@@ -10,13 +10,13 @@ type versionManifest []struct {
 	Version string `json:"version"`
 }
 
-// GameVersions fetches the list of all Minecraft Fabric-supported game versions from the official FabricMC API.
+// GameVersions fetches the list of all Minecraft Fabric-supported game versions from the Fabric meta API (FabricMC or Legacy Fabric).
 // It uses a default background context.
 func (p *Provider) GameVersions() ([]string, error) {
 	return p.GameVersionsContext(context.Background())
 }
 
-// GameVersionsContext fetches the list of all Minecraft Fabric-supported game versions from the official FabricMC API with context support.
+// GameVersionsContext fetches the list of all Minecraft Fabric-supported game versions from the Fabric meta API (FabricMC or Legacy Fabric) with context support.
 //
 // Parameters:
 //   - ctx: the context to control the request lifetime.
@@ -25,10 +25,10 @@ func (p *Provider) GameVersions() ([]string, error) {
 //   - []string: a slice of Minecraft versions supported by Fabric (e.g., "1.20.5", "1.18-pre2", "20w51a").
 //   - error: an error if any HTTP or JSON decoding issues occur.
 func (p *Provider) GameVersionsContext(ctx context.Context) ([]string, error) {
-	p.Log("Fetching supported Fabric game versions...")
+	p.Log("Fetching supported %s game versions...", p.name)
 
 	// URL of the version manifest containing all Minecraft fabric versions
-	const url = "https://meta2.fabricmc.net/v2/versions/game"
+	url := p.metaURL + "/v2/versions/game"
 
 	// Fetch and decode the fabric version manifest
 	var versionData versionManifest
@@ -42,7 +42,7 @@ func (p *Provider) GameVersionsContext(ctx context.Context) ([]string, error) {
 		versions = append(versions, version.Version)
 	}
 
-	p.Log("Fetched %d Fabric game versions", len(versions))
+	p.Log("Fetched %d %s game versions", len(versions), p.name)
 
 	return versions, nil
 }
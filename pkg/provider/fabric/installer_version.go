@@ -0,0 +1,35 @@
+package fabric
+
+import (
+	"context"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// InstallerVersion describes a Fabric installer release.
+type InstallerVersion struct {
+	Version string `json:"version"`
+	Stable  bool   `json:"stable"`
+}
+
+// InstallerVersions fetches the list of all available installer versions.
+// It uses a default background context.
+func (p *Provider) InstallerVersions() ([]InstallerVersion, error) {
+	return p.InstallerVersionsContext(context.Background())
+}
+
+// InstallerVersionsContext fetches the list of all available installer versions with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//
+// Returns:
+//   - []InstallerVersion: the installer versions, newest first, with their stable flag.
+//   - error: an error if any HTTP or JSON decoding issues occur.
+func (p *Provider) InstallerVersionsContext(ctx context.Context) ([]InstallerVersion, error) {
+	var installerVersions []InstallerVersion
+	if err := internal.FetchJSON(ctx, p.metaURL+"/v2/versions/installer", &installerVersions); err != nil {
+		return nil, err
+	}
+	return installerVersions, nil
+}
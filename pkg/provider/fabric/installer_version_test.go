@@ -0,0 +1,63 @@
+package fabric
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstallerVersionPinning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/versions/installer":
+			fmt.Fprint(w, `[{"version":"1.1.0","stable":false},{"version":"1.0.1","stable":true}]`)
+		case "/v2/versions/loader/1.21.5", "/v2/versions/loader/1.21.5/0.16.14":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Provider{name: "Fabric", metaURL: server.URL, downloadURL: server.URL}
+
+	t.Run("installer versions", func(t *testing.T) {
+		versions, err := p.InstallerVersions()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(versions) != 2 || versions[0].Version != "1.1.0" || versions[0].Stable || !versions[1].Stable {
+			t.Errorf("unexpected installer versions: %+v", versions)
+		}
+	})
+
+	testCases := []struct {
+		name             string
+		installerVersion string
+		expectedURL      string
+		wantErr          bool
+	}{
+		{"latest", "", server.URL + "/v2/versions/loader/1.21.5/0.16.14/1.1.0/server/jar", false},
+		{"pinned", "1.0.1", server.URL + "/v2/versions/loader/1.21.5/0.16.14/1.0.1/server/jar", false},
+		{"unknown", "0.0.1", "", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p.SetInstallerVersion(tc.installerVersion)
+			url, err := p.DownloadURL("1.21.5", "0.16.14")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got URL %s", url)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if url != tc.expectedURL {
+				t.Errorf("expected %s, got %s", tc.expectedURL, url)
+			}
+		})
+	}
+}
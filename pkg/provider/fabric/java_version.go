@@ -0,0 +1,56 @@
+package fabric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
+
+type loaderProfileManifest struct {
+	LauncherMeta struct {
+		MinJavaVersion int `json:"min_java_version"`
+	} `json:"launcherMeta"`
+}
+
+// RequiredJavaVersion returns the minimum Java major version required to run the Fabric server.
+// It uses a default background context.
+func (p *Provider) RequiredJavaVersion(gameVersion, serverVersion string) (int, error) {
+	return p.RequiredJavaVersionContext(context.Background(), gameVersion, serverVersion)
+}
+
+// RequiredJavaVersionContext returns the minimum Java major version required to run the Fabric server with context support.
+// The requirement from the Mojang version details is raised to the minimum the loader version declares in its launcher metadata.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.5", "25w14craftmine", "1.18-pre2").
+//   - serverVersion: the Fabric loader version (e.g., "0.16.14").
+//
+// Returns:
+//   - int: the required Java major version (e.g., 8, 17, 21).
+//   - error: an error if the game version or loader version is not found, or if any HTTP or JSON decoding issues occur.
+func (p *Provider) RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error) {
+	required, err := vanilla.JavaBaseline{}.RequiredJavaVersionContext(ctx, gameVersion, "")
+	if err != nil {
+		return 0, err
+	}
+
+	return p.loaderJavaVersion(ctx, gameVersion, serverVersion, required)
+}
+
+// loaderJavaVersion raises the required Java version to the minimum declared by the loader's launcher metadata.
+func (p *Provider) loaderJavaVersion(ctx context.Context, gameVersion, serverVersion string, required int) (int, error) {
+	url := fmt.Sprintf("%s/v2/versions/loader/%s/%s", p.metaURL, gameVersion, serverVersion)
+
+	var profileData loaderProfileManifest
+	if err := internal.FetchJSON(ctx, url, &profileData); errors.Is(err, internal.ErrNotFound) {
+		return 0, fmt.Errorf("loader version %s not found for version %s", serverVersion, gameVersion)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s loader %s for %s: %w", p.name, serverVersion, gameVersion, err)
+	}
+
+	return max(required, profileData.LauncherMeta.MinJavaVersion), nil
+}
@@ -0,0 +1,62 @@
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoaderJavaVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/versions/loader/1.16.5/0.16.14":
+			fmt.Fprint(w, `{"launcherMeta":{"version":2,"min_java_version":8}}`)
+		case "/v2/versions/loader/1.16.5/0.99.0":
+			fmt.Fprint(w, `{"launcherMeta":{"version":2,"min_java_version":17}}`)
+		case "/v2/versions/loader/1.12.2/0.14.0":
+			fmt.Fprint(w, `{"launcherMeta":{"version":1}}`)
+		case "/v2/versions/loader/1.16.5/0.15.0":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := &Provider{name: "Fabric", metaURL: server.URL, downloadURL: server.URL}
+
+	testCases := []struct {
+		name          string
+		gameVersion   string
+		serverVersion string
+		baseline      int
+		expected      int
+		wantErr       string
+	}{
+		{"baseline satisfies loader", "1.16.5", "0.16.14", 8, 8, ""},
+		{"loader raises requirement", "1.16.5", "0.99.0", 8, 17, ""},
+		{"loader without requirement", "1.12.2", "0.14.0", 8, 8, ""},
+		{"unknown loader", "1.16.5", "0.0.1", 8, 0, "loader version 0.0.1 not found"},
+		{"server error", "1.16.5", "0.15.0", 8, 0, "unexpected status 503"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			required, err := p.loaderJavaVersion(context.Background(), tc.gameVersion, tc.serverVersion, tc.baseline)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected an error containing %q, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if required != tc.expected {
+				t.Errorf("expected Java %d, got %d", tc.expected, required)
+			}
+		})
+	}
+}
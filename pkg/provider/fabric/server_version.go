@@ -12,13 +12,13 @@ type loaderVersionManifest []struct {
 	Version string `json:"version"`
 }
 
-// ServerVersions fetches the list of all available Fabric loader versions from the official FabricMC API.
+// ServerVersions fetches the list of all available Fabric loader versions from the Fabric meta API (FabricMC or Legacy Fabric).
 // It uses a default background context.
 func (p *Provider) ServerVersions(gameVersion string) ([]string, error) {
 	return p.ServerVersionsContext(context.Background(), gameVersion)
 }
 
-// ServerVersionsContext fetches the list of all available Fabric loader versions from the official FabricMC API with context support.
+// ServerVersionsContext fetches the list of all available Fabric loader versions from the Fabric meta API (FabricMC or Legacy Fabric) with context support.
 // It also verifies that the provided game version is supported by Fabric.
 //
 // Parameters:
@@ -29,11 +29,11 @@ func (p *Provider) ServerVersions(gameVersion string) ([]string, error) {
 //   - []string: a slice of Fabric loader versions (e.g., "0.16.14", "0.15.11").
 //   - error: an error if the game version is not supported or if any HTTP or JSON decoding issues occur.
 func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string) ([]string, error) {
-	p.Log("Fetching Fabric server versions (loaders) for %s...", gameVersion)
+	p.Log("Fetching %s server versions (loaders) for %s...", p.name, gameVersion)
 
 	// Check Fabric support for the given version
 	// This avoids downloading the large JSON body when we only need to check existence
-	checkURL := fmt.Sprintf("https://meta2.fabricmc.net/v2/versions/loader/%s", gameVersion)
+	checkURL := fmt.Sprintf("%s/v2/versions/loader/%s", p.metaURL, gameVersion)
 
 	// Create request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checkURL, nil)
@@ -57,7 +57,7 @@ func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string
 	}
 
 	// URL of the version manifest containing all Minecraft fabric loader versions
-	const url = "https://meta2.fabricmc.net/v2/versions/loader"
+	url := p.metaURL + "/v2/versions/loader"
 
 	// Fetch and decode JSON the fabric loader manifest
 	var loaderData loaderVersionManifest
@@ -71,7 +71,7 @@ func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string
 		versions = append(versions, version.Version)
 	}
 
-	p.Log("Fetched %d Fabric loader versions", len(versions))
+	p.Log("Fetched %d %s loader versions", len(versions), p.name)
 
 	return versions, nil
 }
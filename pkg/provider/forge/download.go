@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/forgeinstaller"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
 )
 
@@ -19,6 +21,8 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 
 // DownloadContext downloads the Forge server files to the specified installation directory with context support.
 // It handles both standard installer JARs and legacy zip patches (which require merging with a vanilla server JAR).
+// If the native installation is enabled (see SetNativeInstall) or the installer run is enabled (see SetRunInstaller),
+// the server is installed from the installer JAR and the installer is removed afterwards.
 //
 // Parameters:
 //   - ctx: the context to control the download cancellation.
@@ -28,7 +32,7 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 //   - onProgress: a callback function to report download progress.
 //
 // Returns:
-//   - error: an error if the download or patching (for legacy versions) fails.
+//   - error: an error if the download, the installation or patching (for legacy versions) fails.
 func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
 	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
 	if err != nil {
@@ -42,6 +46,23 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 		if err := internal.Download(ctx, url, installerPath, onProgress); err != nil {
 			return err
 		}
+		if err := maven.VerifyChecksum(ctx, url, installerPath); err != nil {
+			os.Remove(installerPath)
+			return err
+		}
+
+		if p.nativeInstall {
+			return p.installNatively(ctx, gameVersion, installerPath, installDir)
+		}
+		if p.runInstaller {
+			p.Log("Running Forge installer...")
+			if err := forgeinstaller.RunInstaller(ctx, installerPath, installDir, p.javaPath, p.Log); err != nil {
+				return err
+			}
+			p.Log("Successfully installed Forge server to %s", installDir)
+			return nil
+		}
+
 		p.Log("Installer downloaded. Please run the following command in the installation directory to complete the server setup:")
 		p.Log("java -jar installer.jar --installServer")
 	} else if strings.HasSuffix(url, ".zip") {
@@ -61,6 +82,10 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 		if err := internal.Download(ctx, url, patchPath, onProgress); err != nil {
 			return err
 		}
+		if err := maven.VerifyChecksum(ctx, url, patchPath); err != nil {
+			os.Remove(patchPath)
+			return err
+		}
 		p.Log("Download complete!")
 
 		// Download the corresponding vanilla server JAR
@@ -74,9 +99,9 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 		}
 		p.Log("Download complete!")
 
-		// Patch the server
+		// Patch the server, dropping the vanilla signatures that no longer match the patched classes
 		p.Log("Patching vanilla server...")
-		if err := internal.MergeZips(ctx, vanillaPath, patchPath, finalJarPath); err != nil {
+		if err := internal.MergeZips(ctx, vanillaPath, patchPath, finalJarPath, internal.StripSignatures); err != nil {
 			return err
 		}
 		p.Log("Successfully created Forge server to %s", installDir)
@@ -86,3 +111,27 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 
 	return nil
 }
+
+// installNatively installs the server from the downloaded installer jar without running the installer,
+// and removes the installer jar on success.
+func (p *Provider) installNatively(ctx context.Context, gameVersion, installerPath, installDir string) error {
+	vanillaURL, err := vanilla.New().DownloadURLContext(ctx, gameVersion, "")
+	if err != nil {
+		return err
+	}
+
+	p.Log("Installing Forge server natively...")
+	err = forgeinstaller.Install(ctx, installerPath, installDir, forgeinstaller.Options{
+		JavaPath:           p.javaPath,
+		MinecraftServerURL: vanillaURL,
+		Repositories:       p.LibraryRepositories(),
+		Log:                p.Log,
+	})
+	if err != nil {
+		return err
+	}
+
+	os.Remove(installerPath)
+	p.Log("Successfully installed Forge server to %s", installDir)
+	return nil
+}
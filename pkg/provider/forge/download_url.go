@@ -3,9 +3,9 @@ package forge
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
 )
 
 // DownloadURL returns the download URL for the Forge server file for a given game version and loader version.
@@ -15,7 +15,7 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 }
 
 // DownloadURLContext returns the download URL for the Forge server file for a given game version and loader version with context support.
-// It determines the correct URL format based on the game version.
+// It determines the server file (installer JAR, or patch zip for legacy versions) from the files published for the version.
 //
 // Parameters:
 //   - ctx: the context to control the request lifetime.
@@ -37,19 +37,8 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 		return "", err
 	}
 
-	// Some game versions have different naming conventions in the manifest
-	var forgeStyleVersion string
-	switch gameVersion {
-	case "1.7.10-pre4":
-		forgeStyleVersion = "1.7.10_pre4"
-	case "1.4":
-		forgeStyleVersion = "1.4.0"
-	default:
-		forgeStyleVersion = gameVersion
-	}
-
 	// Raw loader versions from the manifest (e.g., "1.7.10-10.13.3.1401-1710ls")
-	rawLoaderVersions, ok := loaderData[forgeStyleVersion]
+	rawLoaderVersions, ok := loaderData[forgeStyleVersion(gameVersion)]
 	if !ok {
 		return "", fmt.Errorf("unsupported game version: %s", gameVersion)
 	}
@@ -57,40 +46,51 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 	// Find the matching loader version and construct the URL
 	for i := len(rawLoaderVersions) - 1; i >= 0; i-- {
 		// Extract the loader version from the raw string (e.g., "1.7.10-10.13.3.1401-1710ls" -> "10.13.3.1401")
-		if strings.Split(rawLoaderVersions[i], "-")[1] == serverVersion {
-			var serverURL string
-			// The file extension varies depending on the game version
-			switch gameVersion {
-			case
-				"1.5.1",
-				"1.5",
-				"1.4.7",
-				"1.4.6",
-				"1.4.5",
-				"1.4.4",
-				"1.4.3",
-				"1.4.2",
-				"1.4.1",
-				"1.4.0",
-				"1.3.2":
-				// Older versions use "universal.zip"
-				serverURL = fmt.Sprintf("https://maven.minecraftforge.net/net/minecraftforge/forge/%s/forge-%s-universal.zip", rawLoaderVersions[i], rawLoaderVersions[i])
-			case
-				"1.2.5",
-				"1.2.4",
-				"1.2.3",
-				"1.1":
-				// Very old versions use "server.zip"
-				serverURL = fmt.Sprintf("https://maven.minecraftforge.net/net/minecraftforge/forge/%s/forge-%s-server.zip", rawLoaderVersions[i], rawLoaderVersions[i])
-			default:
-				// Modern versions use "installer.jar"
-				serverURL = fmt.Sprintf("https://maven.minecraftforge.net/net/minecraftforge/forge/%s/forge-%s-installer.jar", rawLoaderVersions[i], rawLoaderVersions[i])
-			}
+		loaderVersion, err := parseLoaderVersion(rawLoaderVersions[i])
+		if err != nil || loaderVersion != serverVersion {
+			continue
+		}
 
-			p.Log("Fetched Forge download URL: %s", serverURL)
-			return serverURL, nil
+		// The server file varies depending on the version, so use what was actually published
+		artifact, err := p.fetchServerArtifact(ctx, gameVersion, rawLoaderVersions[i])
+		if err != nil {
+			return "", err
 		}
+
+		coordinate := maven.Coordinate{
+			Group:      "net.minecraftforge",
+			Artifact:   "forge",
+			Version:    rawLoaderVersions[i],
+			Classifier: artifact.classifier,
+			Extension:  artifact.extension,
+		}
+		serverURL := coordinate.URL(maven.MinecraftForge)
+		p.Log("Fetched Forge download URL: %s", serverURL)
+		return serverURL, nil
 	}
 
 	return "", fmt.Errorf("loader version %s not found for version %s", serverVersion, gameVersion)
 }
+
+// fetchServerArtifact determines the server artifact of a raw Forge version from the classifiers listed in its "meta.json".
+// If the classifiers cannot be fetched, the artifact Forge used for the game version is assumed.
+func (p *Provider) fetchServerArtifact(ctx context.Context, gameVersion, rawVersion string) (serverArtifact, error) {
+	url := fmt.Sprintf("https://files.minecraftforge.net/net/minecraftforge/forge/%s/meta.json", rawVersion)
+
+	var metaData struct {
+		Classifiers map[string]map[string]string `json:"classifiers"`
+	}
+	if err := internal.FetchJSON(ctx, url, &metaData); err != nil {
+		if ctx.Err() != nil {
+			return serverArtifact{}, ctx.Err()
+		}
+		p.Log("Could not fetch the published files of Forge %s, assuming the usual layout: %v", rawVersion, err)
+		return legacyServerArtifact(gameVersion), nil
+	}
+
+	artifact, ok := selectServerArtifact(metaData.Classifiers)
+	if !ok {
+		return serverArtifact{}, fmt.Errorf("forge %s has no server download", rawVersion)
+	}
+	return artifact, nil
+}
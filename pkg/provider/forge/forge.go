@@ -1,11 +1,56 @@
 package forge
 
-import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+import (
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
 
 type Provider struct {
 	provider.BaseProvider
+
+	// The server runs on the same Java as the vanilla server it is based on.
+	vanilla.JavaBaseline
+
+	// nativeInstall enables the native installation instead of leaving the installer to the user.
+	nativeInstall bool
+
+	// runInstaller enables running the downloaded installer with Java.
+	runInstaller bool
+
+	// javaPath is the Java executable used by the installer or the native installation's processor steps.
+	javaPath string
 }
 
 func New() *Provider {
 	return &Provider{}
 }
+
+// SetNativeInstall enables or disables the native installation of the server.
+// When enabled, DownloadContext reads the installer jar, downloads the libraries and runs the
+// processor steps with the given Java executable, so that no manual installer step is needed.
+func (p *Provider) SetNativeInstall(enabled bool, javaPath string) {
+	p.nativeInstall = enabled
+	p.javaPath = javaPath
+}
+
+// SetRunInstaller enables or disables running the downloaded installer.
+// When enabled, DownloadContext runs "java -jar installer.jar --installServer" with the given Java executable,
+// streams its output to the logger and removes the installer files on success.
+// The native installation takes precedence if both are enabled.
+func (p *Provider) SetRunInstaller(enabled bool, javaPath string) {
+	p.runInstaller = enabled
+	p.javaPath = javaPath
+}
+
+// forgeStyleVersion converts a game version into the naming used by the Forge metadata,
+// since some game versions have different naming conventions there.
+func forgeStyleVersion(gameVersion string) string {
+	switch gameVersion {
+	case "1.7.10-pre4":
+		return "1.7.10_pre4"
+	case "1.4":
+		return "1.4.0"
+	default:
+		return gameVersion
+	}
+}
@@ -0,0 +1,78 @@
+package forge
+
+import (
+	"context"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// Promotions holds the loader versions that Forge promotes for a game version.
+type Promotions struct {
+	// Recommended is the recommended loader version (e.g., "47.2.0"), or empty if Forge recommends none.
+	Recommended string
+
+	// Latest is the latest loader version, or empty if Forge promotes none.
+	Latest string
+}
+
+// Promotions fetches the recommended and latest loader versions for a given Minecraft version.
+// It uses a default background context.
+func (p *Provider) Promotions(gameVersion string) (Promotions, error) {
+	return p.PromotionsContext(context.Background(), gameVersion)
+}
+
+// PromotionsContext fetches the recommended and latest loader versions for a given Minecraft version with context support.
+// It retrieves the "<game version>-recommended" and "<game version>-latest" markers from the official Forge promotions.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.20.1", "1.7.10-pre4", "1.4").
+//
+// Returns:
+//   - Promotions: the promoted loader versions; fields are empty for missing markers.
+//   - error: an error if any HTTP or JSON decoding issues occur.
+func (p *Provider) PromotionsContext(ctx context.Context, gameVersion string) (Promotions, error) {
+	p.Log("Fetching Forge promotions for %s...", gameVersion)
+
+	// URL of the promotions containing the recommended and latest loaders of every game version
+	const url = "https://files.minecraftforge.net/net/minecraftforge/forge/promotions_slim.json"
+
+	var promotionData struct {
+		Promos map[string]string `json:"promos"`
+	}
+	if err := internal.FetchJSON(ctx, url, &promotionData); err != nil {
+		return Promotions{}, err
+	}
+
+	version := forgeStyleVersion(gameVersion)
+	promotions := Promotions{
+		Recommended: promotionData.Promos[version+"-recommended"],
+		Latest:      promotionData.Promos[version+"-latest"],
+	}
+
+	p.Log("Forge promotions for %s: recommended %q, latest %q", gameVersion, promotions.Recommended, promotions.Latest)
+	return promotions, nil
+}
+
+// RecommendedServerVersion returns the loader version recommended by Forge for a given Minecraft version.
+// It uses a default background context.
+func (p *Provider) RecommendedServerVersion(gameVersion string) (string, error) {
+	return p.RecommendedServerVersionContext(context.Background(), gameVersion)
+}
+
+// RecommendedServerVersionContext returns the loader version recommended by Forge for a given Minecraft version with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.20.1", "1.7.10-pre4", "1.4").
+//
+// Returns:
+//   - string: the recommended loader version (e.g., "47.2.0"), or an empty string if Forge recommends none.
+//   - error: an error if any HTTP or JSON decoding issues occur.
+func (p *Provider) RecommendedServerVersionContext(ctx context.Context, gameVersion string) (string, error) {
+	promotions, err := p.PromotionsContext(ctx, gameVersion)
+	if err != nil {
+		return "", err
+	}
+	return promotions.Recommended, nil
+}
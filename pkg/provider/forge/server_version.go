@@ -3,7 +3,6 @@ package forge
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
 )
@@ -36,19 +35,8 @@ func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string
 		return nil, err
 	}
 
-	// Some game versions have different naming conventions in the manifest
-	var forgeStyleVersion string
-	switch gameVersion {
-	case "1.7.10-pre4":
-		forgeStyleVersion = "1.7.10_pre4"
-	case "1.4":
-		forgeStyleVersion = "1.4.0"
-	default:
-		forgeStyleVersion = gameVersion
-	}
-
 	// Raw loader versions from the manifest (e.g., "1.7.10-10.13.3.1401-1710ls")
-	rawLoaderVersions, ok := loaderData[forgeStyleVersion]
+	rawLoaderVersions, ok := loaderData[forgeStyleVersion(gameVersion)]
 	if !ok {
 		return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
 	}
@@ -57,7 +45,13 @@ func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string
 	refinedLoadersVersions := make([]string, 0, len(rawLoaderVersions))
 	// Build the slice from last to first (higher versions first)
 	for i := len(rawLoaderVersions) - 1; i >= 0; i-- {
-		refinedLoadersVersions = append(refinedLoadersVersions, strings.Split(rawLoaderVersions[i], "-")[1])
+		loaderVersion, err := parseLoaderVersion(rawLoaderVersions[i])
+		if err != nil {
+			// Skip malformed entries instead of failing the whole list
+			p.Log("Skipping %v", err)
+			continue
+		}
+		refinedLoadersVersions = append(refinedLoadersVersions, loaderVersion)
 	}
 
 	p.Log("Fetched %d Forge loader versions for %s", len(refinedLoadersVersions), gameVersion)
@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// serverArtifact is a classifier and extension under which Forge published server files.
+type serverArtifact struct {
+	classifier string
+	extension  string
+}
+
+// serverArtifacts lists the server artifacts by preference:
+//   - "installer.jar" for 1.5.2 and newer.
+//   - "universal.zip", a patch zip for the vanilla server, for 1.3.2 to 1.5.1.
+//   - "server.zip", a patch zip for the vanilla server, for 1.1 to 1.2.5.
+var serverArtifacts = []serverArtifact{
+	{"installer", "jar"},
+	{"universal", "zip"},
+	{"server", "zip"},
+}
+
+// parseLoaderVersion extracts the loader version from a raw Forge maven version.
+// Raw versions have the form "<game version>-<loader version>[-<branch>]"
+// (e.g., "1.7.10-10.13.3.1401-1710ls" -> "10.13.3.1401").
+func parseLoaderVersion(rawVersion string) (string, error) {
+	parts := strings.SplitN(rawVersion, "-", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid forge version format: %s", rawVersion)
+	}
+	return parts[1], nil
+}
+
+// selectServerArtifact picks the preferred server artifact from the classifiers published for a version,
+// as listed by the Forge "meta.json" ("classifiers": {"installer": {"jar": "<md5>"}, ...}).
+// Versions that only published client or source files (e.g., FML-only builds) have no server artifact.
+func selectServerArtifact(classifiers map[string]map[string]string) (serverArtifact, bool) {
+	for _, artifact := range serverArtifacts {
+		if _, ok := classifiers[artifact.classifier][artifact.extension]; ok {
+			return artifact, true
+		}
+	}
+	return serverArtifact{}, false
+}
+
+// legacyServerArtifact returns the server artifact Forge used for a game version,
+// used when the published classifiers of a version cannot be fetched.
+func legacyServerArtifact(gameVersion string) serverArtifact {
+	switch gameVersion {
+	case "1.5.1", "1.5", "1.4.7", "1.4.6", "1.4.5", "1.4.4", "1.4.3", "1.4.2", "1.4.1", "1.4.0", "1.4", "1.3.2":
+		// Older versions use "universal.zip"
+		return serverArtifact{"universal", "zip"}
+	case "1.2.5", "1.2.4", "1.2.3", "1.1":
+		// Very old versions use "server.zip"
+		return serverArtifact{"server", "zip"}
+	default:
+		// Modern versions use "installer.jar"
+		return serverArtifact{"installer", "jar"}
+	}
+}
@@ -0,0 +1,106 @@
+package forge
+
+import "testing"
+
+func TestParseLoaderVersion(t *testing.T) {
+	testCases := []struct {
+		rawVersion string
+		expected   string
+		wantErr    bool
+	}{
+		{"1.20.1-47.2.0", "47.2.0", false},
+		{"1.7.10-10.13.3.1401-1710ls", "10.13.3.1401", false},
+		{"1.7.10_pre4-10.12.2.1149-prerelease", "10.12.2.1149", false},
+		{"1.2.5-3.4.9.171", "3.4.9.171", false},
+		{"1.1-1.1.0.51", "1.1.0.51", false},
+		{"4.3.5.318", "", true},
+		{"1.20.1-", "", true},
+		{"-47.2.0", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rawVersion, func(t *testing.T) {
+			loaderVersion, err := parseLoaderVersion(tc.rawVersion)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", loaderVersion)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if loaderVersion != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, loaderVersion)
+			}
+		})
+	}
+}
+
+func TestSelectServerArtifact(t *testing.T) {
+	testCases := []struct {
+		name        string
+		classifiers map[string]map[string]string
+		expected    serverArtifact
+		found       bool
+	}{
+		{
+			name: "modern installer",
+			classifiers: map[string]map[string]string{
+				"installer": {"jar": "md5"},
+				"universal": {"jar": "md5"},
+				"mdk":       {"zip": "md5"},
+			},
+			expected: serverArtifact{"installer", "jar"},
+			found:    true,
+		},
+		{
+			name: "1.3.2 to 1.5.1 universal zip",
+			classifiers: map[string]map[string]string{
+				"universal": {"zip": "md5"},
+				"client":    {"zip": "md5"},
+				"src":       {"zip": "md5"},
+			},
+			expected: serverArtifact{"universal", "zip"},
+			found:    true,
+		},
+		{
+			name: "1.1 to 1.2.5 server zip",
+			classifiers: map[string]map[string]string{
+				"client": {"zip": "md5"},
+				"server": {"zip": "md5"},
+				"src":    {"zip": "md5"},
+			},
+			expected: serverArtifact{"server", "zip"},
+			found:    true,
+		},
+		{
+			name: "client only build",
+			classifiers: map[string]map[string]string{
+				"client": {"zip": "md5"},
+				"src":    {"zip": "md5"},
+			},
+			found: false,
+		},
+		{
+			name: "universal jar without installer",
+			classifiers: map[string]map[string]string{
+				"universal": {"jar": "md5"},
+			},
+			found: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact, found := selectServerArtifact(tc.classifiers)
+			if found != tc.found {
+				t.Fatalf("expected found %v, got %v", tc.found, found)
+			}
+			if found && artifact != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, artifact)
+			}
+		})
+	}
+}
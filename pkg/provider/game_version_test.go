@@ -4,8 +4,11 @@ import (
 	"testing"
 
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/bedrock"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/fabric"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/forge"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/githubrelease"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/jenkins"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/neoforge"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/paper"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/purpur"
@@ -21,9 +24,14 @@ func TestGameVersions(t *testing.T) {
 		{"Vanilla", vanilla.New(), false},
 		{"Paper", paper.New(), false},
 		{"Fabric", fabric.New(), false},
+		{"LegacyFabric", fabric.NewLegacy(), false},
 		{"Forge", forge.New(), false},
 		{"NeoForge", neoforge.New(), false},
 		{"Purpur", purpur.New(), false},
+		{"Pufferfish", jenkins.New(jenkins.Pufferfish), false},
+		{"Leaf", githubrelease.New(githubrelease.Leaf), false},
+		{"Canvas", githubrelease.New(githubrelease.Canvas), false},
+		{"Bedrock", bedrock.New(), false},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,17 @@
+package githubrelease
+
+import "regexp"
+
+// Leaf is the definition of the Leaf server, a Paper fork focused on performance and vanilla parity.
+var Leaf = Definition{
+	Name:         "Leaf",
+	Repository:   "Winds-Studio/Leaf",
+	AssetPattern: regexp.MustCompile(`^leaf-(?P<game>[0-9.]+)(?:-[0-9]+)?\.jar$`),
+}
+
+// Canvas is the definition of the Canvas server, a multithreaded Folia fork.
+var Canvas = Definition{
+	Name:         "Canvas",
+	Repository:   "CraftCanvasMC/Canvas",
+	AssetPattern: regexp.MustCompile(`^canvas-(?P<game>[0-9.]+)(?:-[0-9]+)?\.jar$`),
+}
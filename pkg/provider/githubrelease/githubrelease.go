@@ -0,0 +1,72 @@
+package githubrelease
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/buildlist"
+)
+
+// Definition describes a server type that is published as GitHub release assets.
+type Definition struct {
+	// Name is the display name used in log messages (e.g., "Leaf").
+	Name string
+
+	// APIURL is the base URL of the GitHub API. Defaults to the public GitHub API if empty.
+	APIURL string
+
+	// Repository is the GitHub repository in "owner/name" form (e.g., "Winds-Studio/Leaf").
+	Repository string
+
+	// AssetPattern matches the file name of the server jar.
+	// It must contain a named group "game" capturing the game version.
+	AssetPattern *regexp.Regexp
+}
+
+type Provider struct {
+	buildlist.Provider
+
+	definition Definition
+}
+
+func New(definition Definition) *Provider {
+	if definition.APIURL == "" {
+		definition.APIURL = internal.GitHubAPIURL
+	}
+
+	p := &Provider{definition: definition}
+	p.Provider = buildlist.Provider{Name: definition.Name, Noun: "release", Fetch: p.fetchServerReleases}
+	return p
+}
+
+// fetchServerReleases fetches the releases of the configured repository and keeps
+// the ones that contain an asset matching the definition's pattern.
+// Every release has to be fetched to find those of a game version, so gameVersion is ignored.
+func (p *Provider) fetchServerReleases(ctx context.Context, gameVersion string) ([]buildlist.Build, error) {
+	gameIndex := p.definition.AssetPattern.SubexpIndex("game")
+
+	releases, err := internal.FetchGitHubReleases(ctx, p.definition.APIURL, p.definition.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var serverReleases []buildlist.Build
+	for _, release := range releases {
+		for _, asset := range release.Assets {
+			match := p.definition.AssetPattern.FindStringSubmatch(asset.Name)
+			if match == nil || gameIndex < 0 {
+				continue
+			}
+
+			serverReleases = append(serverReleases, buildlist.Build{
+				GameVersion:   match[gameIndex],
+				ServerVersion: release.TagName,
+				URL:           asset.BrowserDownloadURL,
+			})
+			break
+		}
+	}
+
+	return serverReleases, nil
+}
@@ -0,0 +1,94 @@
+package githubrelease_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/githubrelease"
+)
+
+func TestProvider(t *testing.T) {
+	var testServer *httptest.Server
+	testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/owner/server/releases" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/server/releases?page=2>; rel="next"`, testServer.URL))
+			fmt.Fprintf(w, `[
+				{"tag_name":"v3","draft":true,"assets":[{"name":"server-1.21.4.jar","browser_download_url":"%[1]s/v3/server-1.21.4.jar"}]},
+				{"tag_name":"v2","assets":[{"name":"server-1.21.4.jar","browser_download_url":"%[1]s/v2/server-1.21.4.jar"}]}
+			]`, testServer.URL)
+		case r.URL.Path == "/repos/owner/server/releases":
+			fmt.Fprintf(w, `[
+				{"tag_name":"v1","assets":[{"name":"sources.zip","browser_download_url":"%[1]s/v1/sources.zip"},{"name":"server-1.21.4.jar","browser_download_url":"%[1]s/v1/server-1.21.4.jar"}]},
+				{"tag_name":"v0","assets":[{"name":"server-1.20.6.jar","browser_download_url":"%[1]s/v0/server-1.20.6.jar"}]}
+			]`, testServer.URL)
+		case r.URL.Path == "/v1/server-1.21.4.jar":
+			fmt.Fprint(w, "server")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer testServer.Close()
+
+	provider := githubrelease.New(githubrelease.Definition{
+		Name:         "Server",
+		APIURL:       testServer.URL,
+		Repository:   "owner/server",
+		AssetPattern: regexp.MustCompile(`^server-(?P<game>[0-9.]+)\.jar$`),
+	})
+
+	t.Run("game versions", func(t *testing.T) {
+		versions, err := provider.GameVersions()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(versions, []string{"1.21.4", "1.20.6"}) {
+			t.Errorf("unexpected game versions %v", versions)
+		}
+	})
+
+	t.Run("server versions span pages", func(t *testing.T) {
+		releases, err := provider.ServerVersions("1.21.4")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(releases, []string{"v2", "v1"}) {
+			t.Errorf("unexpected releases %v", releases)
+		}
+
+		if _, err := provider.ServerVersions("1.19.4"); err == nil {
+			t.Error("expected error for an unsupported game version, got nil")
+		}
+	})
+
+	t.Run("download url", func(t *testing.T) {
+		url, err := provider.DownloadURL("1.21.4", "v1")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if want := testServer.URL + "/v1/server-1.21.4.jar"; url != want {
+			t.Errorf("expected %s, got %s", want, url)
+		}
+
+		if _, err := provider.DownloadURL("1.21.4", "v3"); err == nil {
+			t.Error("expected error for a draft release, got nil")
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := provider.Download("1.21.4", "v1", dir, nil); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, "server.jar"))
+		if err != nil || string(content) != "server" {
+			t.Errorf("unexpected server.jar %q (%v)", content, err)
+		}
+	})
+}
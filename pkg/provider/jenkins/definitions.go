@@ -0,0 +1,16 @@
+package jenkins
+
+import "regexp"
+
+// Pufferfish is the definition of the Pufferfish server, a performance-oriented Paper fork.
+var Pufferfish = Definition{
+	Name: "Pufferfish",
+	Jobs: []Job{
+		{URL: "https://ci.pufferfish.host/job/Pufferfish-1.21", GameVersion: "1.21"},
+		{URL: "https://ci.pufferfish.host/job/Pufferfish-1.20", GameVersion: "1.20"},
+		{URL: "https://ci.pufferfish.host/job/Pufferfish-1.19", GameVersion: "1.19"},
+		{URL: "https://ci.pufferfish.host/job/Pufferfish-1.18", GameVersion: "1.18"},
+		{URL: "https://ci.pufferfish.host/job/Pufferfish-1.17", GameVersion: "1.17"},
+	},
+	ArtifactPattern: regexp.MustCompile(`^pufferfish-paperclip-(?P<game>[0-9.]+)-R0\.1-SNAPSHOT(?:-reobf)?\.jar$`),
+}
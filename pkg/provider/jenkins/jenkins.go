@@ -0,0 +1,105 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/buildlist"
+)
+
+// Definition describes a server type that is published as Jenkins build artifacts.
+type Definition struct {
+	// Name is the display name used in log messages (e.g., "Pufferfish").
+	Name string
+
+	// Jobs lists the Jenkins jobs publishing the server, higher game versions first.
+	Jobs []Job
+
+	// ArtifactPattern matches the file name of the server jar.
+	// It must contain a named group "game" capturing the game version.
+	ArtifactPattern *regexp.Regexp
+}
+
+// Job is a Jenkins job publishing the server.
+type Job struct {
+	// URL is the URL of the job (e.g., "https://ci.pufferfish.host/job/Pufferfish-1.21").
+	URL string
+
+	// GameVersion is the game version line the job builds (e.g., "1.21" for 1.21, 1.21.1, ...).
+	// The job is only queried for the versions of its line; an empty GameVersion means any game version.
+	GameVersion string
+}
+
+// builds reports whether the job builds a game version.
+func (j Job) builds(gameVersion string) bool {
+	return j.GameVersion == "" || gameVersion == j.GameVersion || strings.HasPrefix(gameVersion, j.GameVersion+".")
+}
+
+type Provider struct {
+	buildlist.Provider
+
+	definition Definition
+}
+
+func New(definition Definition) *Provider {
+	p := &Provider{definition: definition}
+	p.Provider = buildlist.Provider{Name: definition.Name, Noun: "build", Fetch: p.fetchServerBuilds}
+	return p
+}
+
+// fetchServerBuilds fetches the builds of the jobs building a game version (every job if gameVersion is empty)
+// and keeps the successful ones that contain an artifact matching the definition's pattern.
+// A job that cannot be fetched is skipped as long as another one could be.
+func (p *Provider) fetchServerBuilds(ctx context.Context, gameVersion string) ([]buildlist.Build, error) {
+	gameIndex := p.definition.ArtifactPattern.SubexpIndex("game")
+
+	var serverBuilds []buildlist.Build
+	var errs []error
+	fetched := false
+	for _, job := range p.definition.Jobs {
+		if gameVersion != "" && !job.builds(gameVersion) {
+			continue
+		}
+
+		builds, err := internal.FetchJenkinsBuilds(ctx, job.URL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			p.Log("Skipping Jenkins job %s: %v", job.URL, err)
+			errs = append(errs, err)
+			continue
+		}
+		fetched = true
+
+		for _, build := range builds {
+			// Skip failed, aborted and still running builds
+			if build.Result != "SUCCESS" {
+				continue
+			}
+
+			for _, artifact := range build.Artifacts {
+				match := p.definition.ArtifactPattern.FindStringSubmatch(artifact.FileName)
+				if match == nil || gameIndex < 0 {
+					continue
+				}
+
+				serverBuilds = append(serverBuilds, buildlist.Build{
+					GameVersion:   match[gameIndex],
+					ServerVersion: strconv.Itoa(build.Number),
+					URL:           internal.JenkinsArtifactURL(job.URL, build.Number, artifact),
+				})
+				break
+			}
+		}
+	}
+
+	if !fetched && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return serverBuilds, nil
+}
@@ -0,0 +1,116 @@
+package jenkins_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/jenkins"
+)
+
+func TestProvider(t *testing.T) {
+	const jobJSON = `{"builds":[
+		{"number":12,"result":"SUCCESS","artifacts":[{"fileName":"server-1.21.4.jar","relativePath":"build/server-1.21.4.jar"}]},
+		{"number":11,"result":"FAILURE","artifacts":[{"fileName":"server-1.21.4.jar","relativePath":"build/server-1.21.4.jar"}]},
+		{"number":10,"result":"SUCCESS","artifacts":[{"fileName":"server-1.21.3.jar","relativePath":"build/server-1.21.3.jar"}]},
+		{"number":9,"result":"SUCCESS","artifacts":[{"fileName":"server-1.21.4.jar","relativePath":"build/server-1.21.4.jar"}]}
+	]}`
+
+	var mu sync.Mutex
+	var requests []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/job/Server-1.21/api/json":
+			fmt.Fprint(w, jobJSON)
+		case "/job/Server-1.21/12/artifact/build/server-1.21.4.jar":
+			fmt.Fprint(w, "server")
+		default:
+			// The 1.20 job is gone
+			http.Error(w, "gone", http.StatusInternalServerError)
+		}
+	}))
+	defer testServer.Close()
+
+	provider := jenkins.New(jenkins.Definition{
+		Name: "Server",
+		Jobs: []jenkins.Job{
+			{URL: testServer.URL + "/job/Server-1.21", GameVersion: "1.21"},
+			{URL: testServer.URL + "/job/Server-1.20", GameVersion: "1.20"},
+		},
+		ArtifactPattern: regexp.MustCompile(`^server-(?P<game>[0-9.]+)\.jar$`),
+	})
+
+	t.Run("game versions skip dead jobs", func(t *testing.T) {
+		versions, err := provider.GameVersions()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(versions, []string{"1.21.4", "1.21.3"}) {
+			t.Errorf("unexpected game versions %v", versions)
+		}
+	})
+
+	t.Run("server versions query only the job of the version", func(t *testing.T) {
+		mu.Lock()
+		requests = nil
+		mu.Unlock()
+
+		builds, err := provider.ServerVersions("1.21.4")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !slices.Equal(builds, []string{"12", "9"}) {
+			t.Errorf("unexpected builds %v", builds)
+		}
+		if !slices.Equal(requests, []string{"/job/Server-1.21/api/json"}) {
+			t.Errorf("unexpected requests %v", requests)
+		}
+	})
+
+	t.Run("dead job", func(t *testing.T) {
+		if _, err := provider.ServerVersions("1.20.4"); err == nil {
+			t.Error("expected error for the dead job, got nil")
+		}
+	})
+
+	t.Run("unsupported game version", func(t *testing.T) {
+		if _, err := provider.ServerVersions("1.19.4"); err == nil {
+			t.Error("expected error for an unsupported game version, got nil")
+		}
+	})
+
+	t.Run("download url", func(t *testing.T) {
+		url, err := provider.DownloadURL("1.21.4", "12")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if want := testServer.URL + "/job/Server-1.21/12/artifact/build/server-1.21.4.jar"; url != want {
+			t.Errorf("expected %s, got %s", want, url)
+		}
+
+		if _, err := provider.DownloadURL("1.21.4", "11"); err == nil {
+			t.Error("expected error for a failed build, got nil")
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := provider.Download("1.21.4", "12", dir, nil); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, "server.jar"))
+		if err != nil || string(content) != "server" {
+			t.Errorf("unexpected server.jar %q (%v)", content, err)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package neoforge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+// Checksum returns the checksum the Maven repository publishes for the NeoForge installer JAR of a given game version and loader version.
+// It uses a default background context.
+func (p *Provider) Checksum(gameVersion, serverVersion string) (provider.Checksum, error) {
+	return p.ChecksumContext(context.Background(), gameVersion, serverVersion)
+}
+
+// ChecksumContext returns the checksum the Maven repository publishes for the NeoForge installer JAR of a given game version and loader version with context support.
+// It reads the ".sha256" or ".sha1" file next to the download URL.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.6", "25w14craftmine", "1.21").
+//   - serverVersion: the NeoForge loader version string (e.g., "21.0.142-beta", "0.25w14craftmine.5-beta").
+//
+// Returns:
+//   - provider.Checksum: the checksum of "installer.jar".
+//   - error: provider.ErrNoChecksum if no checksum is published for an installer JAR, or an error if the versions are not found or the checksum cannot be fetched.
+func (p *Provider) ChecksumContext(ctx context.Context, gameVersion, serverVersion string) (provider.Checksum, error) {
+	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return provider.Checksum{}, err
+	}
+	if !strings.HasSuffix(url, ".jar") {
+		return provider.Checksum{}, fmt.Errorf("neoforge %s loader %s has no installer: %w", gameVersion, serverVersion, provider.ErrNoChecksum)
+	}
+
+	algorithm, digest, err := maven.FetchChecksum(ctx, url)
+	if errors.Is(err, maven.ErrNotFound) {
+		return provider.Checksum{}, fmt.Errorf("neoforge %s loader %s: %w", gameVersion, serverVersion, provider.ErrNoChecksum)
+	}
+	if err != nil {
+		return provider.Checksum{}, err
+	}
+	return provider.Checksum{Path: "installer.jar", Algorithm: algorithm, Digest: digest}, nil
+}
+
+// LibraryRepositories returns the Maven repositories the NeoForge installer resolves the server libraries from.
+func (p *Provider) LibraryRepositories() []string {
+	return []string{maven.NeoForged, maven.MinecraftLibraries, maven.Central}
+}
@@ -2,9 +2,13 @@ package neoforge
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/forgeinstaller"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
 )
 
 // Download downloads the NeoForge installer JAR to the specified installation directory.
@@ -14,6 +18,8 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 }
 
 // DownloadContext downloads the NeoForge installer JAR to the specified installation directory with context support.
+// If the native installation is enabled (see SetNativeInstall) or the installer run is enabled (see SetRunInstaller),
+// the server is installed from the installer JAR and the installer is removed afterwards.
 //
 // Parameters:
 //   - ctx: the context to control the download cancellation.
@@ -23,7 +29,7 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 //   - onProgress: a callback function to report download progress.
 //
 // Returns:
-//   - error: an error if the download fails.
+//   - error: an error if the download or the installation fails.
 func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
 	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
 	if err != nil {
@@ -34,9 +40,52 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 
 	serverJarPath := filepath.Join(installDir, "installer.jar")
 	err = internal.Download(ctx, url, serverJarPath, onProgress)
+	if err != nil {
+		return err
+	}
+	if err := maven.VerifyChecksum(ctx, url, serverJarPath); err != nil {
+		os.Remove(serverJarPath)
+		return err
+	}
+
+	if p.nativeInstall {
+		return p.installNatively(ctx, gameVersion, serverJarPath, installDir)
+	}
+	if p.runInstaller {
+		p.Log("Running NeoForge installer...")
+		if err := forgeinstaller.RunInstaller(ctx, serverJarPath, installDir, p.javaPath, p.Log); err != nil {
+			return err
+		}
+		p.Log("Successfully installed NeoForge server to %s", installDir)
+		return nil
+	}
 
 	p.Log("Installer downloaded. Please run the following command in the installation directory to complete the server setup:")
 	p.Log("java -jar installer.jar --installServer")
 
-	return err
+	return nil
+}
+
+// installNatively installs the server from the downloaded installer jar without running the installer,
+// and removes the installer jar on success.
+func (p *Provider) installNatively(ctx context.Context, gameVersion, installerPath, installDir string) error {
+	vanillaURL, err := vanilla.New().DownloadURLContext(ctx, gameVersion, "")
+	if err != nil {
+		return err
+	}
+
+	p.Log("Installing NeoForge server natively...")
+	err = forgeinstaller.Install(ctx, installerPath, installDir, forgeinstaller.Options{
+		JavaPath:           p.javaPath,
+		MinecraftServerURL: vanillaURL,
+		Repositories:       p.LibraryRepositories(),
+		Log:                p.Log,
+	})
+	if err != nil {
+		return err
+	}
+
+	os.Remove(installerPath)
+	p.Log("Successfully installed NeoForge server to %s", installDir)
+	return nil
 }
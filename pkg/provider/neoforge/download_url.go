@@ -22,7 +22,7 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 //
 // Returns:
 //   - string: the direct download URL for the NeoForge server installer/archive file if the versions exist.
-//   - error: an error if the game version or loader version is not found, or if any HTTP or XML decoding issues occur.
+//   - error: an error if the game version or loader version is not found, or if the maven metadata cannot be fetched.
 func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
 	p.Log("Fetching download URL for NeoForge %s loader %s...", gameVersion, serverVersion)
 
@@ -37,7 +37,10 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 		return "", fmt.Errorf("loader version %s not found for version %s", serverVersion, gameVersion)
 	}
 
-	serverURL := fmt.Sprintf("https://maven.neoforged.net/releases/net/neoforged/neoforge/%s/neoforge-%s-installer.jar", serverVersion, serverVersion)
+	serverURL, err := mavenClient.ResolveURL(ctx, installerCoordinate(serverVersion))
+	if err != nil {
+		return "", err
+	}
 	p.Log("Fetched NeoForge download URL: %s", serverURL)
 	return serverURL, nil
 }
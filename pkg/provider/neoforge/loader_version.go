@@ -0,0 +1,24 @@
+package neoforge
+
+import (
+	"context"
+
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+)
+
+// mavenClient resolves NeoForge artifacts from the official NeoForged maven.
+var mavenClient = maven.NewClient(maven.NeoForged)
+
+// installerCoordinate returns the maven coordinate of the installer jar of a NeoForge loader version.
+func installerCoordinate(loaderVersion string) maven.Coordinate {
+	return maven.Coordinate{Group: "net.neoforged", Artifact: "neoforge", Version: loaderVersion, Classifier: "installer"}
+}
+
+// fetchLoaderVersions fetches every published NeoForge loader version from the maven metadata, oldest first.
+func fetchLoaderVersions(ctx context.Context) ([]string, error) {
+	metadata, err := mavenClient.Metadata(ctx, "net.neoforged", "neoforge")
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Versioning.Versions, nil
+}
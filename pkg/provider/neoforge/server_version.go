@@ -2,9 +2,7 @@ package neoforge
 
 import (
 	"context"
-	"encoding/xml"
 	"fmt"
-	"net/http"
 )
 
 // ServerVersions fetches a list of available NeoForge loader versions for a given Minecraft version.
@@ -22,41 +20,19 @@ func (p *Provider) ServerVersions(gameVersion string) ([]string, error) {
 //
 // Returns:
 //   - []string: a slice of NeoForge loader versions (e.g., "21.0.142-beta", "0.25w14craftmine.5-beta").
-//   - error: an error if the game version is not supported or if any HTTP or XML decoding issues occur.
+//   - error: an error if the game version is not supported or if the maven metadata cannot be fetched.
 func (p *Provider) ServerVersionsContext(ctx context.Context, gameVersion string) ([]string, error) {
 	p.Log("Fetching NeoForge server versions (loaders) for %s...", gameVersion)
 
-	// URL of the version manifest containing all Minecraft neoforge versions
-	const url = "https://maven.neoforged.net/releases/net/neoforged/neoforge/maven-metadata.xml"
-
-	// Create a new HTTP request with context
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
-	}
-
-	// Send HTTP GET request
-	response, err := http.DefaultClient.Do(req)
+	loaderVersions, err := fetchLoaderVersions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch XML from %s: %w", url, err)
-	}
-	defer response.Body.Close()
-
-	// Check for a successful HTTP response
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status %d when fetching XML from %s", response.StatusCode, url)
-	}
-
-	// Decode the XML response into the provided variable
-	var versionData versionManifest
-	if err := xml.NewDecoder(response.Body).Decode(&versionData); err != nil {
-		return nil, fmt.Errorf("failed to decode XML from %s: %w", url, err)
+		return nil, err
 	}
 
 	// Filter loader versions that match the requested game version
-	matchingLoaderVersions := make([]string, 0, len(versionData.Versioning.Versions.Version))
-	for _, loaderVersion := range versionData.Versioning.Versions.Version {
-		currentGameVersion, err := parseGameVersion(loaderVersion)
+	matchingLoaderVersions := make([]string, 0, len(loaderVersions))
+	for _, loaderVersion := range loaderVersions {
+		currentGameVersion, err := ParseGameVersion(loaderVersion)
 		if err != nil {
 			return nil, err
 		}
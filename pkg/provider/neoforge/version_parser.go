@@ -5,7 +5,8 @@ import (
 	"strings"
 )
 
-func parseGameVersion(loaderVersion string) (string, error) {
+// ParseGameVersion returns the game version a NeoForge version is built for (e.g., "21.1.77" -> "1.21.1").
+func ParseGameVersion(loaderVersion string) (string, error) {
 	versionParts := strings.SplitN(loaderVersion, ".", 4)
 
 	switch versionParts[0] {
@@ -0,0 +1,37 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+// Checksum returns the SHA-256 checksum Fill publishes for the PaperMC server JAR of a given game version and build number.
+// It uses a default background context.
+func (p *Provider) Checksum(gameVersion, serverVersion string) (provider.Checksum, error) {
+	return p.ChecksumContext(context.Background(), gameVersion, serverVersion)
+}
+
+// ChecksumContext returns the SHA-256 checksum Fill publishes for the PaperMC server JAR of a given game version and build number with context support.
+// The checksum of the selected variant (see SetVariant) is returned, "default" if none was selected.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.16.5", "1.13-pre7").
+//   - serverVersion: the PaperMC build number for the specified version.
+//
+// Returns:
+//   - provider.Checksum: the checksum of "server.jar".
+//   - error: an error if the game version, build number or variant is not found, or if any HTTP or JSON decoding issues occur.
+func (p *Provider) ChecksumContext(ctx context.Context, gameVersion, serverVersion string) (provider.Checksum, error) {
+	download, err := p.fetchDownload(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return provider.Checksum{}, err
+	}
+
+	if download.Checksums.SHA256 == "" {
+		return provider.Checksum{}, fmt.Errorf("paper %s build %s: %w", gameVersion, serverVersion, provider.ErrNoChecksum)
+	}
+	return provider.Checksum{Path: "server.jar", Algorithm: "sha256", Digest: download.Checksums.SHA256}, nil
+}
@@ -2,6 +2,8 @@ package paper
 
 import (
 	"context"
+	"errors"
+	"os"
 	"path/filepath"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
@@ -14,6 +16,8 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 }
 
 // DownloadContext downloads the PaperMC server JAR to the specified installation directory with context support.
+// The selected variant (see SetVariant) is downloaded and verified against its published SHA-256 checksum.
+// If patching is enabled (see SetPatchPaperclip), the Paperclip patches are applied after the download.
 //
 // Parameters:
 //   - ctx: the context to control the download cancellation.
@@ -23,9 +27,9 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 //   - onProgress: a callback function to report download progress.
 //
 // Returns:
-//   - error: an error if the download fails.
+//   - error: an error if the download or patching fails.
 func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
-	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
+	download, err := p.fetchDownload(ctx, gameVersion, serverVersion)
 	if err != nil {
 		return err
 	}
@@ -33,9 +37,30 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 	p.Log("Downloading server...")
 
 	serverJarPath := filepath.Join(installDir, "server.jar")
-	err = internal.Download(ctx, url, serverJarPath, onProgress)
+	if err := internal.Download(ctx, download.URL, serverJarPath, onProgress); err != nil {
+		return err
+	}
+	if download.Checksums.SHA256 != "" {
+		if err := internal.VerifyFile(serverJarPath, "sha256", download.Checksums.SHA256); err != nil {
+			os.Remove(serverJarPath)
+			return err
+		}
+	}
 
 	p.Log("Successfully downloaded server to %s", installDir)
 
-	return err
+	if p.patchPaperclip {
+		p.Log("Applying Paperclip patches...")
+		err := internal.ApplyPaperclip(ctx, serverJarPath, installDir, p.Log)
+		if errors.Is(err, internal.ErrNotPaperclip) {
+			p.Log("server.jar is not a Paperclip launcher, skipping the patches")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p.Log("Paperclip patches applied, the server can now start without network access")
+	}
+
+	return nil
 }
@@ -5,14 +5,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 )
 
-type detailManifest struct {
-	Downloads struct {
-		ServerDefault struct {
-			URL string `json:"url"`
-		} `json:"server:default"`
-	} `json:"downloads"`
+// buildManifest is a single build as returned by the Fill v3 API.
+type buildManifest struct {
+	ID        int                         `json:"id"`
+	Channel   string                      `json:"channel"`
+	Downloads map[string]downloadManifest `json:"downloads"`
+}
+
+// downloadManifest is a single entry of the "downloads" object of a build (e.g., "server:default").
+type downloadManifest struct {
+	Name      string `json:"name"`
+	Checksums struct {
+		SHA256 string `json:"sha256"`
+	} `json:"checksums"`
+	Size int64  `json:"size"`
+	URL  string `json:"url"`
 }
 
 // DownloadURL returns the download URL for the PaperMC server JAR for a given game version and build number.
@@ -22,6 +33,7 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 }
 
 // DownloadURLContext returns the download URL for the PaperMC server JAR for a given game version and build number with context support.
+// The URL of the selected variant (see SetVariant) is returned, "default" if none was selected.
 //
 // Parameters:
 //   - ctx: the context to control the request lifetime.
@@ -30,23 +42,55 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 //
 // Returns:
 //   - string: the direct download URL for the PaperMC server JAR file if the build exists.
-//   - error: an error if the game version or build number is not found, or if any HTTP or JSON decoding issues occur.
+//   - error: an error if the game version, build number or variant is not found, or if any HTTP or JSON decoding issues occur.
 func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
 	p.Log("Fetching download URL for Paper %s build %s...", gameVersion, serverVersion)
 
+	download, err := p.fetchDownload(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return "", err
+	}
+
+	p.Log("Fetched Paper download URL: %s", download.URL)
+	return download.URL, nil
+}
+
+// fetchDownload fetches a build and returns the download entry of the selected variant.
+func (p *Provider) fetchDownload(ctx context.Context, gameVersion, serverVersion string) (*downloadManifest, error) {
+	build, err := fetchBuild(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := p.variant
+	if variant == "" {
+		variant = DefaultVariant
+	}
+
+	download, ok := build.Downloads["server:"+strings.TrimPrefix(variant, "server:")]
+	if !ok {
+		variants := variantNames(build.Downloads)
+		return nil, fmt.Errorf("variant %s not found for Paper %s build %s (available: %s)", variant, gameVersion, serverVersion, strings.Join(variants, ", "))
+	}
+
+	return &download, nil
+}
+
+// fetchBuild fetches a single build from the Fill v3 API.
+func fetchBuild(ctx context.Context, gameVersion, serverVersion string) (*buildManifest, error) {
 	// URL to validate the existence of a specific build
 	url := fmt.Sprintf("https://fill.papermc.io/v3/projects/paper/versions/%s/builds/%s", gameVersion, serverVersion)
 
 	// Create a new HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request for %s: %w", url, err)
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
 	}
 
 	// Send HTTP GET request
 	response, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch JSON from %s: %w", url, err)
+		return nil, fmt.Errorf("failed to fetch JSON from %s: %w", url, err)
 	}
 	defer response.Body.Close()
 
@@ -57,29 +101,38 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 			Error string `json:"error"`
 		}
 		if err := json.NewDecoder(response.Body).Decode(&errorValue); err != nil {
-			return "", fmt.Errorf("failed to decode error JSON from %s: %w", url, err)
+			return nil, fmt.Errorf("failed to decode error JSON from %s: %w", url, err)
 		}
 
 		switch errorValue.Error {
 		case "version_not_found":
-			return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+			return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
 		case "build_not_found":
-			return "", fmt.Errorf("build number %s not found for version %s", gameVersion, gameVersion)
+			return nil, fmt.Errorf("build number %s not found for version %s", serverVersion, gameVersion)
 		default:
-			return "", fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+			return nil, fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
 		}
 	case http.StatusOK:
 		// Handle successful response
-		var versionInfo detailManifest
-		if err := json.NewDecoder(response.Body).Decode(&versionInfo); err != nil {
-			return "", fmt.Errorf("failed to decode JSON from %s: %w", url, err)
+		var build buildManifest
+		if err := json.NewDecoder(response.Body).Decode(&build); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON from %s: %w", url, err)
 		}
-
-		serverURL := versionInfo.Downloads.ServerDefault.URL
-		p.Log("Fetched Paper download URL: %s", serverURL)
-		return serverURL, nil
+		return &build, nil
 	default:
 		// Handle other unexpected statuses
-		return "", fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+		return nil, fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+	}
+}
+
+// variantNames returns the sorted variant names of the server downloads of a build (e.g., "default", "mojmap").
+func variantNames(downloads map[string]downloadManifest) []string {
+	names := make([]string, 0, len(downloads))
+	for key := range downloads {
+		if name, ok := strings.CutPrefix(key, "server:"); ok {
+			names = append(names, name)
+		}
 	}
+	slices.Sort(names)
+	return names
 }
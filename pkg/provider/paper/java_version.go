@@ -0,0 +1,56 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
+
+type javaVersionManifest struct {
+	Version struct {
+		Java struct {
+			Version struct {
+				Minimum int `json:"minimum"`
+			} `json:"version"`
+		} `json:"java"`
+	} `json:"version"`
+}
+
+// RequiredJavaVersion returns the minimum Java major version required to run the Paper server.
+// It uses a default background context.
+func (p *Provider) RequiredJavaVersion(gameVersion, serverVersion string) (int, error) {
+	return p.RequiredJavaVersionContext(context.Background(), gameVersion, serverVersion)
+}
+
+// RequiredJavaVersionContext returns the minimum Java major version required to run the Paper server with context support.
+// The requirement from the Mojang version details is raised to the minimum Paper declares for the game version.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.16.5", "1.13-pre7").
+//   - serverVersion: ignored, every Paper build of a game version has the same requirement.
+//
+// Returns:
+//   - int: the required Java major version (e.g., 8, 17, 21).
+//   - error: an error if the game version is not supported or if any HTTP or JSON decoding issues occur.
+func (p *Provider) RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error) {
+	required, err := vanilla.JavaBaseline{}.RequiredJavaVersionContext(ctx, gameVersion, "")
+	if err != nil {
+		return 0, err
+	}
+
+	// Version manifest URL for the specified game version
+	url := fmt.Sprintf("https://fill.papermc.io/v3/projects/paper/versions/%s", gameVersion)
+
+	var versionData javaVersionManifest
+	if err := internal.FetchJSON(ctx, url, &versionData); errors.Is(err, internal.ErrNotFound) {
+		return 0, fmt.Errorf("unsupported game version: %s", gameVersion)
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to fetch the Paper Java requirement for %s: %w", gameVersion, err)
+	}
+
+	return max(required, versionData.Version.Java.Version.Minimum), nil
+}
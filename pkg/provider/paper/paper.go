@@ -2,10 +2,33 @@ package paper
 
 import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
 
+// DefaultVariant is the variant downloaded when none is selected.
+const DefaultVariant = "default"
+
 type Provider struct {
 	provider.BaseProvider
+
+	// variant is the selected download variant (e.g., "mojmap"); empty means DefaultVariant.
+	variant string
+
+	// patchPaperclip enables applying the Paperclip patches after the download.
+	patchPaperclip bool
 }
 
 func New() *Provider {
 	return &Provider{}
 }
+
+// SetPatchPaperclip enables or disables applying the Paperclip patches.
+// When enabled, DownloadContext downloads the vanilla server into "cache/", applies the bsdiff patches
+// and extracts the server and libraries into "versions/" and "libraries/", just like the launcher does on its first run.
+// Server jars older than Minecraft 1.18 are not patched.
+func (p *Provider) SetPatchPaperclip(enabled bool) {
+	p.patchPaperclip = enabled
+}
+
+// SetVariant selects the download variant of the server jar (e.g., "default" or "mojmap").
+// Not every build provides every variant; ServerVersionInfos lists the available ones.
+func (p *Provider) SetVariant(variant string) {
+	p.variant = variant
+}
@@ -0,0 +1,77 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+)
+
+// Variant describes a downloadable server jar of a Paper build (e.g., the default or the Mojang-mapped jar).
+type Variant struct {
+	Name     string // the variant name, as accepted by SetVariant (e.g., "default", "mojmap")
+	FileName string // the file name of the jar (e.g., "paper-1.21.4-232.jar")
+	URL      string // the download URL
+	SHA256   string // the hex-encoded SHA-256 checksum
+	Size     int64  // the file size in bytes
+}
+
+// ServerVersionInfo describes a Paper build and its downloadable variants.
+type ServerVersionInfo struct {
+	Build    string    // the build number, as accepted as server version (e.g., "232")
+	Channel  string    // the release channel (e.g., "STABLE", "BETA")
+	Variants []Variant // the variants sorted by name
+}
+
+// ServerVersionInfos fetches the builds of a game version with their downloadable variants.
+// It uses a default background context.
+func (p *Provider) ServerVersionInfos(gameVersion string) ([]ServerVersionInfo, error) {
+	return p.ServerVersionInfosContext(context.Background(), gameVersion)
+}
+
+// ServerVersionInfosContext fetches the builds of a game version with their downloadable variants with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.16.5", "1.13-pre7").
+//
+// Returns:
+//   - []ServerVersionInfo: the builds, newest first, with the checksums and sizes of their variants.
+//   - error: an error if the game version is not supported or if any HTTP or JSON decoding issues occur.
+func (p *Provider) ServerVersionInfosContext(ctx context.Context, gameVersion string) ([]ServerVersionInfo, error) {
+	p.Log("Fetching Paper build details for %s...", gameVersion)
+
+	url := fmt.Sprintf("https://fill.papermc.io/v3/projects/paper/versions/%s/builds", gameVersion)
+
+	var builds []buildManifest
+	if err := internal.FetchJSON(ctx, url, &builds); errors.Is(err, internal.ErrNotFound) {
+		return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to fetch Paper builds for %s: %w", gameVersion, err)
+	}
+
+	// Sort the builds newest first, like ServerVersions
+	slices.SortFunc(builds, func(a, b buildManifest) int { return b.ID - a.ID })
+
+	infos := make([]ServerVersionInfo, 0, len(builds))
+	for _, build := range builds {
+		info := ServerVersionInfo{Build: strconv.Itoa(build.ID), Channel: build.Channel}
+		for _, name := range variantNames(build.Downloads) {
+			download := build.Downloads["server:"+name]
+			info.Variants = append(info.Variants, Variant{
+				Name:     name,
+				FileName: download.Name,
+				URL:      download.URL,
+				SHA256:   download.Checksums.SHA256,
+				Size:     download.Size,
+			})
+		}
+		infos = append(infos, info)
+	}
+
+	p.Log("Fetched %d Paper build details for %s", len(infos), gameVersion)
+	return infos, nil
+}
@@ -1,6 +1,9 @@
 package provider
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // Logger defines the interface for logging messages.
 // It abstraction allows the application to inject standard log.Logger,
@@ -32,6 +35,23 @@ func (b *BaseProvider) Log(format string, v ...any) {
 	b.logger.Printf(format, v...)
 }
 
+// NativeInstaller is implemented by providers whose server setup normally requires running a Java installer
+// (e.g., Forge and NeoForge). When the native installation is enabled, the provider performs the
+// installation itself and only delegates the processor steps to Java.
+type NativeInstaller interface {
+	// SetNativeInstall enables or disables the native installation.
+	// javaPath is the Java executable used for the processor steps; if empty, it is discovered from JAVA_HOME or the PATH.
+	SetNativeInstall(enabled bool, javaPath string)
+}
+
+// InstallerRunner is implemented by providers that download a Java installer (e.g., Forge and NeoForge)
+// and can run it themselves after the download.
+type InstallerRunner interface {
+	// SetRunInstaller enables or disables running "java -jar installer.jar --installServer" after the download.
+	// javaPath is the Java executable to use; if empty, it is discovered from JAVA_HOME or the PATH.
+	SetRunInstaller(enabled bool, javaPath string)
+}
+
 // Provider defines the standard interface that all Minecraft server providers must implement.
 type Provider interface {
 	// GameVersions returns a list of available game versions (e.g., "1.16.5", "15w14a", "1.18-pre2").
@@ -70,3 +90,88 @@ type Provider interface {
 	// Log logs a message using the injected logger, if available.
 	Log(format string, v ...any)
 }
+
+// BundlerExtractor is implemented by providers whose server jar is a Mojang bundler (Minecraft 1.18 and newer).
+// When the extraction is enabled, the bundled server and libraries are unpacked into "versions/" and "libraries/"
+// so that the server can be started with a plain classpath.
+type BundlerExtractor interface {
+	// SetExtractBundler enables or disables the bundler extraction.
+	SetExtractBundler(enabled bool)
+}
+
+// PaperclipPatcher is implemented by providers whose server jar is a Paperclip launcher (e.g., Paper).
+// When patching is enabled, the provider performs the launcher's first-run setup itself, so that the
+// server starts without network access.
+type PaperclipPatcher interface {
+	// SetPatchPaperclip enables or disables applying the Paperclip patches after the download.
+	SetPatchPaperclip(enabled bool)
+}
+
+// VariantSelector is implemented by providers that publish several jars per server version
+// (e.g., Paper's Mojang-mapped "mojmap" jar next to the default one).
+type VariantSelector interface {
+	// SetVariant selects the variant to download. An empty string selects the default variant.
+	SetVariant(variant string)
+}
+
+// InstallerVersionSelector is implemented by providers whose server launcher is built by a versioned installer
+// (e.g., Fabric and Legacy Fabric). Pinning the installer version makes the downloaded launcher reproducible.
+type InstallerVersionSelector interface {
+	// SetInstallerVersion pins the installer version. An empty string selects the latest installer.
+	SetInstallerVersion(version string)
+}
+
+// RecommendedServerVersioner is implemented by providers that publish a recommended server version
+// per game version (e.g., Forge's promoted "recommended" builds).
+type RecommendedServerVersioner interface {
+	// RecommendedServerVersion returns the recommended server version, or an empty string if there is none.
+	// It is equivalent to calling RecommendedServerVersionContext with context.Background().
+	RecommendedServerVersion(gameVersion string) (string, error)
+
+	// RecommendedServerVersionContext returns the recommended server version with context support.
+	RecommendedServerVersionContext(ctx context.Context, gameVersion string) (string, error)
+}
+
+// JavaVersionRequirer is implemented by providers of Java edition servers.
+// The requirement is based on the Mojang version details, raised where the server software needs a newer Java.
+type JavaVersionRequirer interface {
+	// RequiredJavaVersion returns the minimum Java major version (e.g., 8, 17, 21) required to run the server.
+	// It is equivalent to calling RequiredJavaVersionContext with context.Background().
+	RequiredJavaVersion(gameVersion, serverVersion string) (int, error)
+
+	// RequiredJavaVersionContext returns the minimum Java major version required to run the server with context support.
+	RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error)
+}
+
+// ErrNoChecksum is returned by ChecksumProvider when upstream publishes no checksum for a server download.
+var ErrNoChecksum = errors.New("no published checksum")
+
+// Checksum is the digest upstream publishes for a server download.
+type Checksum struct {
+	// Path is the file the download is saved as, relative to the install directory (e.g., "server.jar").
+	Path string
+
+	// Algorithm is the hash algorithm of the digest ("md5", "sha1" or "sha256").
+	Algorithm string
+
+	// Digest is the hex-encoded digest of the file.
+	Digest string
+}
+
+// ChecksumProvider is implemented by providers whose download service publishes a checksum of the server download
+// (e.g., Mojang's SHA-1, Fill's SHA-256, Purpur's MD5 or the checksum files of a Maven repository).
+type ChecksumProvider interface {
+	// Checksum returns the published checksum of the server download.
+	// It is equivalent to calling ChecksumContext with context.Background().
+	Checksum(gameVersion, serverVersion string) (Checksum, error)
+
+	// ChecksumContext returns the published checksum of the server download with context support.
+	ChecksumContext(ctx context.Context, gameVersion, serverVersion string) (Checksum, error)
+}
+
+// LibraryRepositoryProvider is implemented by providers whose installs fill "libraries/" from Maven repositories
+// (e.g., Forge and NeoForge). The checksum files of the repositories verify the installed libraries.
+type LibraryRepositoryProvider interface {
+	// LibraryRepositories returns the base URLs of the repositories, in the order the install tries them.
+	LibraryRepositories() []string
+}
@@ -0,0 +1,36 @@
+package purpur
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+// Checksum returns the MD5 checksum Purpur publishes for the PurpurMC server JAR of a given game version and build number.
+// It uses a default background context.
+func (p *Provider) Checksum(gameVersion, serverVersion string) (provider.Checksum, error) {
+	return p.ChecksumContext(context.Background(), gameVersion, serverVersion)
+}
+
+// ChecksumContext returns the MD5 checksum Purpur publishes for the PurpurMC server JAR of a given game version and build number with context support.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.21.11", "1.14.1").
+//   - serverVersion: the PurpurMC build number for the specified version.
+//
+// Returns:
+//   - provider.Checksum: the checksum of "server.jar".
+//   - error: an error if the game version or build number is not found, or if any HTTP or JSON decoding issues occur.
+func (p *Provider) ChecksumContext(ctx context.Context, gameVersion, serverVersion string) (provider.Checksum, error) {
+	build, err := fetchBuild(ctx, gameVersion, serverVersion)
+	if err != nil {
+		return provider.Checksum{}, err
+	}
+
+	if build.MD5 == "" {
+		return provider.Checksum{}, fmt.Errorf("purpur %s build %s: %w", gameVersion, serverVersion, provider.ErrNoChecksum)
+	}
+	return provider.Checksum{Path: "server.jar", Algorithm: "md5", Digest: build.MD5}, nil
+}
@@ -34,19 +34,36 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
 	p.Log("Fetching download URL for Purpur %s build %s...", gameVersion, serverVersion)
 
+	if _, err := fetchBuild(ctx, gameVersion, serverVersion); err != nil {
+		return "", err
+	}
+
+	serverURL := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/download", gameVersion, serverVersion)
+	p.Log("Fetched Purpur download URL: %s", serverURL)
+	return serverURL, nil
+}
+
+// buildManifest is a single build as returned by the Purpur v2 API.
+type buildManifest struct {
+	Build string `json:"build"`
+	MD5   string `json:"md5"`
+}
+
+// fetchBuild fetches a single build from the Purpur v2 API.
+func fetchBuild(ctx context.Context, gameVersion, serverVersion string) (*buildManifest, error) {
 	// URL to validate the existence of a specific build
 	url := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s", gameVersion, serverVersion)
 
 	// Create a new HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request for %s: %w", url, err)
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
 	}
 
 	// Send HTTP GET request
 	response, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch JSON from %s: %w", url, err)
+		return nil, fmt.Errorf("failed to fetch JSON from %s: %w", url, err)
 	}
 	defer response.Body.Close()
 
@@ -57,24 +74,26 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 			Error string `json:"error"`
 		}
 		if err := json.NewDecoder(response.Body).Decode(&errorValue); err != nil {
-			return "", fmt.Errorf("failed to decode error JSON from %s: %w", url, err)
+			return nil, fmt.Errorf("failed to decode error JSON from %s: %w", url, err)
 		}
 
 		switch errorValue.Error {
 		case "version not found":
-			return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+			return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
 		case "build not found":
-			return "", fmt.Errorf("build number %s not found for version %s", gameVersion, gameVersion)
+			return nil, fmt.Errorf("build number %s not found for version %s", serverVersion, gameVersion)
 		default:
-			return "", fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+			return nil, fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
 		}
 	case http.StatusOK:
 		// Handle successful response
-		serverURL := fmt.Sprintf("https://api.purpurmc.org/v2/purpur/%s/%s/download", gameVersion, serverVersion)
-		p.Log("Fetched Purpur download URL: %s", serverURL)
-		return serverURL, nil
+		var build buildManifest
+		if err := json.NewDecoder(response.Body).Decode(&build); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON from %s: %w", url, err)
+		}
+		return &build, nil
 	default:
 		// Handle other unexpected statuses
-		return "", fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
+		return nil, fmt.Errorf("unexpected status %d when fetching JSON from %s", response.StatusCode, url)
 	}
 }
@@ -1,9 +1,15 @@
 package purpur
 
-import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
+import (
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/vanilla"
+)
 
 type Provider struct {
 	provider.BaseProvider
+
+	// The server runs on the same Java as the vanilla server it is based on.
+	vanilla.JavaBaseline
 }
 
 func New() *Provider {
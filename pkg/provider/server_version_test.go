@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider/bedrock"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/fabric"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/forge"
 	"github.com/abulleDev/mcserverdl/v2/pkg/provider/neoforge"
@@ -23,9 +24,11 @@ func TestServerVersions(t *testing.T) {
 		{"Vanilla", "", vanilla.New(), true, true},
 		{"Paper", "1.12.2", paper.New(), false, true},
 		{"Fabric", "", fabric.New(), false, true},
+		{"LegacyFabric", "1.8.9", fabric.NewLegacy(), false, true},
 		{"Forge", "1.21.5", forge.New(), false, true},
 		{"NeoForge", "1.21.5", neoforge.New(), false, true},
 		{"Purpur", "1.21.11", purpur.New(), false, true},
+		{"Bedrock", "", bedrock.New(), true, true},
 	}
 
 	for _, tc := range testCases {
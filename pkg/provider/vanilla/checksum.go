@@ -0,0 +1,41 @@
+package vanilla
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/provider"
+)
+
+// Checksum returns the SHA-1 checksum Mojang publishes for the server JAR of a given game version.
+// It uses a default background context.
+func (p *Provider) Checksum(gameVersion, serverVersion string) (provider.Checksum, error) {
+	return p.ChecksumContext(context.Background(), gameVersion, serverVersion)
+}
+
+// ChecksumContext returns the SHA-1 checksum Mojang publishes for the server JAR of a given game version with context support.
+// The checksum is that of the downloaded bundler jar, so it doesn't apply to servers unpacked with SetExtractBundler.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.16.5", "15w14a", "1.18-pre2").
+//   - serverVersion: ignored for vanilla as it doesn't have separate server versions.
+//
+// Returns:
+//   - provider.Checksum: the checksum of "server.jar".
+//   - error: an error if the version is not found, has no server download, or if any HTTP or JSON decoding issues occur.
+func (p *Provider) ChecksumContext(ctx context.Context, gameVersion, serverVersion string) (provider.Checksum, error) {
+	detailData, err := p.fetchDetail(ctx, gameVersion)
+	if err != nil {
+		return provider.Checksum{}, err
+	}
+
+	if detailData.Downloads.Server == nil {
+		return provider.Checksum{}, fmt.Errorf("server download not available for version %s", gameVersion)
+	}
+	if detailData.Downloads.Server.SHA1 == "" {
+		return provider.Checksum{}, fmt.Errorf("vanilla %s: %w", gameVersion, provider.ErrNoChecksum)
+	}
+
+	return provider.Checksum{Path: "server.jar", Algorithm: "sha1", Digest: detailData.Downloads.Server.SHA1}, nil
+}
@@ -2,7 +2,10 @@ package vanilla
 
 import (
 	"context"
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/abulleDev/mcserverdl/v2/internal"
 )
@@ -14,6 +17,7 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 }
 
 // DownloadContext downloads the vanilla server JAR to the specified installation directory with context support.
+// If the bundler extraction is enabled (see SetExtractBundler), the server JAR is unpacked into a runnable layout.
 //
 // Parameters:
 //   - ctx: the context to control the download cancellation.
@@ -23,7 +27,7 @@ func (p *Provider) Download(gameVersion, serverVersion, installDir string, onPro
 //   - onProgress: a callback function to report download progress.
 //
 // Returns:
-//   - error: an error if the download fails.
+//   - error: an error if the download or the bundler extraction fails.
 func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersion, installDir string, onProgress func(current, total int64)) error {
 	url, err := p.DownloadURLContext(ctx, gameVersion, serverVersion)
 	if err != nil {
@@ -33,9 +37,46 @@ func (p *Provider) DownloadContext(ctx context.Context, gameVersion, serverVersi
 	p.Log("Downloading server...")
 
 	serverJarPath := filepath.Join(installDir, "server.jar")
-	err = internal.Download(ctx, url, serverJarPath, onProgress)
+	if err := internal.Download(ctx, url, serverJarPath, onProgress); err != nil {
+		return err
+	}
 
 	p.Log("Successfully downloaded server to %s", installDir)
 
-	return err
+	if p.extractBundler {
+		return p.unpackBundler(ctx, serverJarPath, installDir)
+	}
+	return nil
+}
+
+// unpackBundler extracts a bundler server jar into the install directory and writes server_args.txt,
+// a Java arguments file holding the classpath and main class of the extracted server.
+func (p *Provider) unpackBundler(ctx context.Context, serverJarPath, installDir string) error {
+	p.Log("Extracting bundled server and libraries...")
+
+	layout, err := internal.ExtractBundler(ctx, serverJarPath, installDir)
+	if errors.Is(err, internal.ErrNotBundler) {
+		p.Log("server.jar is not a bundler, keeping it as is")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Arguments files take one argument per line, so the classpath only has to be joined with the platform separator
+	classpath := make([]string, 0, len(layout.Classpath))
+	for _, path := range layout.Classpath {
+		classpath = append(classpath, filepath.FromSlash(path))
+	}
+	args := "-cp\n" + strings.Join(classpath, string(os.PathListSeparator)) + "\n" + layout.MainClass + "\n"
+	if err := os.WriteFile(filepath.Join(installDir, "server_args.txt"), []byte(args), 0644); err != nil {
+		return err
+	}
+
+	if err := os.Remove(serverJarPath); err != nil {
+		return err
+	}
+
+	p.Log("Extracted %d jars. Start the server with: java @server_args.txt nogui", len(layout.Classpath))
+	return nil
 }
@@ -10,9 +10,13 @@ import (
 type detailManifest struct {
 	Downloads struct {
 		Server *struct {
-			URL string `json:"url"`
+			SHA1 string `json:"sha1"`
+			URL  string `json:"url"`
 		} `json:"server"`
 	} `json:"downloads"`
+	JavaVersion *struct {
+		MajorVersion int `json:"majorVersion"`
+	} `json:"javaVersion"`
 }
 
 // DownloadURL returns the download URL for the Minecraft vanilla server JAR for a given game version.
@@ -34,13 +38,31 @@ func (p *Provider) DownloadURL(gameVersion, serverVersion string) (string, error
 func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVersion string) (string, error) {
 	p.Log("Fetching download URL for Vanilla Minecraft %s...", gameVersion)
 
+	detailData, err := p.fetchDetail(ctx, gameVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// Return an error if the server download is not available
+	if detailData.Downloads.Server == nil {
+		return "", fmt.Errorf("server download not available for version %s", gameVersion)
+	}
+
+	// Return the server JAR download URL
+	serverURL := detailData.Downloads.Server.URL
+	p.Log("Fetched vanilla download URL: %s", serverURL)
+	return serverURL, nil
+}
+
+// fetchDetail fetches the version detail manifest of a game version.
+func (p *Provider) fetchDetail(ctx context.Context, gameVersion string) (*detailManifest, error) {
 	// URL of the version manifest containing all Minecraft vanilla versions
 	const url = "https://piston-meta.mojang.com/mc/game/version_manifest_v2.json"
 
 	// Fetch and decode the version manifest
 	var versionData versionManifest
 	if err := internal.FetchJSON(ctx, url, &versionData); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Find the detail URL for the requested game version
@@ -54,7 +76,7 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 
 	// Return an error if the version is not found
 	if detailURL == "" {
-		return "", fmt.Errorf("unsupported game version: %s", gameVersion)
+		return nil, fmt.Errorf("unsupported game version: %s", gameVersion)
 	}
 
 	p.Log("Fetching version details...")
@@ -62,16 +84,8 @@ func (p *Provider) DownloadURLContext(ctx context.Context, gameVersion, serverVe
 	// Fetch and decode the version detail manifest
 	var detailData detailManifest
 	if err := internal.FetchJSON(ctx, detailURL, &detailData); err != nil {
-		return "", err
-	}
-
-	// Return an error if the server download is not available
-	if detailData.Downloads.Server == nil {
-		return "", fmt.Errorf("server download not available for version %s", gameVersion)
+		return nil, err
 	}
 
-	// Return the server JAR download URL
-	serverURL := detailData.Downloads.Server.URL
-	p.Log("Fetched vanilla download URL: %s", serverURL)
-	return serverURL, nil
+	return &detailData, nil
 }
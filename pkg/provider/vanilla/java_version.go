@@ -0,0 +1,52 @@
+package vanilla
+
+import "context"
+
+// legacyJavaVersion is the Java major version assumed for versions whose details don't list one.
+// Mojang only added "javaVersion" to the version details when Minecraft moved past Java 8.
+const legacyJavaVersion = 8
+
+// RequiredJavaVersion returns the minimum Java major version required to run the server of a given game version.
+// It uses a default background context.
+func (p *Provider) RequiredJavaVersion(gameVersion, serverVersion string) (int, error) {
+	return p.RequiredJavaVersionContext(context.Background(), gameVersion, serverVersion)
+}
+
+// RequiredJavaVersionContext returns the minimum Java major version required to run the server of a given game version with context support.
+// It reads "javaVersion.majorVersion" from the Mojang version details, which is the baseline for every Java edition provider.
+//
+// Parameters:
+//   - ctx: the context to control the request lifetime.
+//   - gameVersion: the Minecraft version string (e.g., "1.16.5", "15w14a", "1.18-pre2").
+//   - serverVersion: ignored for vanilla.
+//
+// Returns:
+//   - int: the required Java major version (e.g., 8, 17, 21).
+//   - error: an error if the version is not found or if any HTTP or JSON decoding issues occur.
+func (p *Provider) RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error) {
+	detailData, err := p.fetchDetail(ctx, gameVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	if detailData.JavaVersion == nil || detailData.JavaVersion.MajorVersion == 0 {
+		return legacyJavaVersion, nil
+	}
+	return detailData.JavaVersion.MajorVersion, nil
+}
+
+// JavaBaseline implements the JavaVersionRequirer methods with the requirement from the Mojang version details.
+// It is intended to be embedded in providers of servers that have no requirement of their own.
+type JavaBaseline struct{}
+
+// RequiredJavaVersion returns the minimum Java major version required to run the vanilla server of a given game version.
+// It uses a default background context.
+func (JavaBaseline) RequiredJavaVersion(gameVersion, serverVersion string) (int, error) {
+	return New().RequiredJavaVersionContext(context.Background(), gameVersion, "")
+}
+
+// RequiredJavaVersionContext returns the minimum Java major version required to run the vanilla server of a given game version with context support.
+// serverVersion is ignored.
+func (JavaBaseline) RequiredJavaVersionContext(ctx context.Context, gameVersion, serverVersion string) (int, error) {
+	return New().RequiredJavaVersionContext(ctx, gameVersion, "")
+}
@@ -4,8 +4,19 @@ import "github.com/abulleDev/mcserverdl/v2/pkg/provider"
 
 type Provider struct {
 	provider.BaseProvider
+
+	// extractBundler enables unpacking the bundler server jar after the download.
+	extractBundler bool
 }
 
 func New() *Provider {
 	return &Provider{}
 }
+
+// SetExtractBundler enables or disables the bundler extraction.
+// When enabled, DownloadContext unpacks the server and libraries of a bundler server jar into "versions/" and "libraries/",
+// writes the launch arguments to server_args.txt and removes server.jar.
+// Server jars older than Minecraft 1.18 are not bundlers and are left as they are.
+func (p *Provider) SetExtractBundler(enabled bool) {
+	p.extractBundler = enabled
+}
@@ -0,0 +1,138 @@
+package serverconfig
+
+import "fmt"
+
+// ServerPort returns the TCP port the server listens on ("server-port").
+func (p *Properties) ServerPort() (int, error) {
+	return p.GetInt("server-port")
+}
+
+// SetServerPort sets the TCP port the server listens on. It must be between 1 and 65535.
+func (p *Properties) SetServerPort(port int) error {
+	return p.SetInt("server-port", port)
+}
+
+// MOTD returns the message shown in the server list ("motd").
+func (p *Properties) MOTD() (string, error) {
+	return p.GetString("motd")
+}
+
+// SetMOTD sets the message shown in the server list. Any Unicode text is escaped as the server expects.
+func (p *Properties) SetMOTD(motd string) error {
+	return p.SetString("motd", motd)
+}
+
+// OnlineMode reports whether players are authenticated with Mojang ("online-mode").
+func (p *Properties) OnlineMode() (bool, error) {
+	return p.GetBool("online-mode")
+}
+
+// SetOnlineMode enables or disables the authentication of players.
+func (p *Properties) SetOnlineMode(enabled bool) error {
+	return p.SetBool("online-mode", enabled)
+}
+
+// ViewDistance returns the view distance in chunks ("view-distance").
+func (p *Properties) ViewDistance() (int, error) {
+	return p.GetInt("view-distance")
+}
+
+// SetViewDistance sets the view distance in chunks. It must be between 3 and 32.
+func (p *Properties) SetViewDistance(chunks int) error {
+	return p.SetInt("view-distance", chunks)
+}
+
+// SimulationDistance returns the distance in chunks in which the world is ticked ("simulation-distance").
+func (p *Properties) SimulationDistance() (int, error) {
+	return p.GetInt("simulation-distance")
+}
+
+// SetSimulationDistance sets the simulation distance in chunks. It must be between 3 and 32.
+func (p *Properties) SetSimulationDistance(chunks int) error {
+	return p.SetInt("simulation-distance", chunks)
+}
+
+// MaxPlayers returns the maximum number of players ("max-players").
+func (p *Properties) MaxPlayers() (int, error) {
+	return p.GetInt("max-players")
+}
+
+// SetMaxPlayers sets the maximum number of players. It must not be negative.
+func (p *Properties) SetMaxPlayers(players int) error {
+	return p.SetInt("max-players", players)
+}
+
+// Difficulty returns the difficulty ("difficulty"). Legacy numeric values are resolved to their names.
+func (p *Properties) Difficulty() (Difficulty, error) {
+	value, err := p.enum("difficulty")
+	return Difficulty(value), err
+}
+
+// SetDifficulty sets the difficulty.
+func (p *Properties) SetDifficulty(difficulty Difficulty) error {
+	return p.SetString("difficulty", string(difficulty))
+}
+
+// Gamemode returns the default game mode ("gamemode"). Legacy numeric values are resolved to their names.
+func (p *Properties) Gamemode() (Gamemode, error) {
+	value, err := p.enum("gamemode")
+	return Gamemode(value), err
+}
+
+// SetGamemode sets the default game mode.
+func (p *Properties) SetGamemode(gamemode Gamemode) error {
+	return p.SetString("gamemode", string(gamemode))
+}
+
+// LevelName returns the name of the world directory ("level-name").
+func (p *Properties) LevelName() (string, error) {
+	return p.GetString("level-name")
+}
+
+// SetLevelName sets the name of the world directory.
+func (p *Properties) SetLevelName(name string) error {
+	return p.SetString("level-name", name)
+}
+
+// LevelSeed returns the seed used to generate the world ("level-seed").
+func (p *Properties) LevelSeed() (string, error) {
+	return p.GetString("level-seed")
+}
+
+// SetLevelSeed sets the seed used to generate the world.
+func (p *Properties) SetLevelSeed(seed string) error {
+	return p.SetString("level-seed", seed)
+}
+
+// PVP reports whether players can fight each other ("pvp").
+func (p *Properties) PVP() (bool, error) {
+	return p.GetBool("pvp")
+}
+
+// SetPVP enables or disables players fighting each other.
+func (p *Properties) SetPVP(enabled bool) error {
+	return p.SetBool("pvp", enabled)
+}
+
+// WhiteList reports whether the whitelist is enabled ("white-list").
+func (p *Properties) WhiteList() (bool, error) {
+	return p.GetBool("white-list")
+}
+
+// SetWhiteList enables or disables the whitelist.
+func (p *Properties) SetWhiteList(enabled bool) error {
+	return p.SetBool("white-list", enabled)
+}
+
+// enum returns the name of an enum property.
+func (p *Properties) enum(key string) (string, error) {
+	value, err := p.lookup(key)
+	if err != nil {
+		return "", err
+	}
+	name, err := enumValue(knownProperties[key], value)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return name, nil
+}
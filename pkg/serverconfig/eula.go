@@ -0,0 +1,43 @@
+package serverconfig
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EULAURL is the Minecraft End User License Agreement the server asks to accept.
+const EULAURL = "https://aka.ms/MinecraftEULA"
+
+// javaDateLayout is the layout of java.util.Date#toString, used by the timestamp comments of the server's files.
+const javaDateLayout = "Mon Jan 02 15:04:05 MST 2006"
+
+// AcceptEULA writes eula.txt into the server directory with "eula=true", as the server would after
+// the EULA was accepted by editing the file. The timestamp comment records when it was accepted.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - now: the time of the acceptance.
+//
+// Returns:
+//   - error: an error if the file cannot be written.
+func AcceptEULA(dir string, now time.Time) error {
+	content := "#By changing the setting below to TRUE you are indicating your agreement to our EULA (" + EULAURL + ").\n" +
+		"#" + now.Format(javaDateLayout) + "\n" +
+		"eula=true\n"
+	return os.WriteFile(filepath.Join(dir, "eula.txt"), []byte(content), 0644)
+}
+
+// EULAAccepted reports whether the eula.txt in the server directory accepts the EULA.
+func EULAAccepted(dir string) (bool, error) {
+	properties, err := LoadProperties(filepath.Join(dir, "eula.txt"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	value, _ := properties.Get("eula")
+	return value == "true", nil
+}
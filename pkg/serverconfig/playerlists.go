@@ -0,0 +1,333 @@
+package serverconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The player list files of a server directory.
+const (
+	OpsFile           = "ops.json"
+	WhitelistFile     = "whitelist.json"
+	BannedPlayersFile = "banned-players.json"
+	BannedIPsFile     = "banned-ips.json"
+)
+
+// banDateLayout is the layout of the "created" and "expires" dates of the ban lists.
+const banDateLayout = "2006-01-02 15:04:05 -0700"
+
+// Defaults of ban entries, as the server's /ban command writes them.
+const (
+	DefaultBanSource  = "Server"
+	DefaultBanReason  = "Banned by an operator."
+	banExpiresForever = "forever"
+)
+
+// Op is an entry of ops.json.
+type Op struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+
+	// Level is the permission level of the operator, from 1 to 4.
+	Level int `json:"level"`
+
+	// BypassesPlayerLimit lets the operator join when the server is full.
+	BypassesPlayerLimit bool `json:"bypassesPlayerLimit"`
+}
+
+// WhitelistEntry is an entry of whitelist.json.
+type WhitelistEntry struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// BannedPlayer is an entry of banned-players.json.
+type BannedPlayer struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// BannedIP is an entry of banned-ips.json.
+type BannedIP struct {
+	IP      string `json:"ip"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// Ban describes a new ban.
+type Ban struct {
+	// Created is the time of the ban.
+	Created time.Time
+
+	// Source is who banned, DefaultBanSource if empty.
+	Source string
+
+	// Expires is when the ban ends. The zero time bans forever.
+	Expires time.Time
+
+	// Reason is shown to the banned player, DefaultBanReason if empty.
+	Reason string
+}
+
+// fields returns the created, source, expires and reason fields of a ban entry.
+func (b Ban) fields() (string, string, string, string) {
+	source, expires, reason := b.Source, banExpiresForever, b.Reason
+	if source == "" {
+		source = DefaultBanSource
+	}
+	if !b.Expires.IsZero() {
+		expires = b.Expires.Format(banDateLayout)
+	}
+	if reason == "" {
+		reason = DefaultBanReason
+	}
+	return b.Created.Format(banDateLayout), source, expires, reason
+}
+
+// LoadOps reads the ops.json of a server directory. A missing file has no entries.
+func LoadOps(dir string) ([]Op, error) {
+	return loadList[Op](dir, OpsFile)
+}
+
+// SaveOps writes the ops.json of a server directory.
+func SaveOps(dir string, ops []Op) error {
+	return saveList(dir, OpsFile, ops)
+}
+
+// AddOp makes a player an operator, or updates the level of an existing operator.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - player: the player, usually returned by a PlayerResolver.
+//   - level: the permission level, from 1 to 4.
+//   - bypassesPlayerLimit: whether the operator can join when the server is full.
+//
+// Returns:
+//   - error: an error if the level is invalid or ops.json cannot be read or written.
+func AddOp(dir string, player Player, level int, bypassesPlayerLimit bool) error {
+	if level < 1 || level > 4 {
+		return fmt.Errorf("invalid op level %d: must be between 1 and 4", level)
+	}
+	if err := player.validate(); err != nil {
+		return err
+	}
+
+	ops, err := LoadOps(dir)
+	if err != nil {
+		return err
+	}
+	ops = removeMatching(ops, func(op Op) bool { return matchesPlayer(op.UUID, op.Name, player.UUID) })
+	ops = append(ops, Op{UUID: player.UUID, Name: player.Name, Level: level, BypassesPlayerLimit: bypassesPlayerLimit})
+	return SaveOps(dir, ops)
+}
+
+// RemoveOp removes the operator with the name or UUID and reports whether one was removed.
+func RemoveOp(dir, nameOrUUID string) (bool, error) {
+	return removeFromList(dir, OpsFile, func(op Op) bool { return matchesPlayer(op.UUID, op.Name, nameOrUUID) })
+}
+
+// LoadWhitelist reads the whitelist.json of a server directory. A missing file has no entries.
+func LoadWhitelist(dir string) ([]WhitelistEntry, error) {
+	return loadList[WhitelistEntry](dir, WhitelistFile)
+}
+
+// SaveWhitelist writes the whitelist.json of a server directory.
+func SaveWhitelist(dir string, entries []WhitelistEntry) error {
+	return saveList(dir, WhitelistFile, entries)
+}
+
+// AddToWhitelist adds a player to the whitelist, replacing an entry with the same UUID.
+func AddToWhitelist(dir string, player Player) error {
+	if err := player.validate(); err != nil {
+		return err
+	}
+
+	entries, err := LoadWhitelist(dir)
+	if err != nil {
+		return err
+	}
+	entries = removeMatching(entries, func(entry WhitelistEntry) bool {
+		return matchesPlayer(entry.UUID, entry.Name, player.UUID)
+	})
+	entries = append(entries, WhitelistEntry{UUID: player.UUID, Name: player.Name})
+	return SaveWhitelist(dir, entries)
+}
+
+// RemoveFromWhitelist removes the player with the name or UUID from the whitelist and reports whether one was removed.
+func RemoveFromWhitelist(dir, nameOrUUID string) (bool, error) {
+	return removeFromList(dir, WhitelistFile, func(entry WhitelistEntry) bool {
+		return matchesPlayer(entry.UUID, entry.Name, nameOrUUID)
+	})
+}
+
+// LoadBannedPlayers reads the banned-players.json of a server directory. A missing file has no entries.
+func LoadBannedPlayers(dir string) ([]BannedPlayer, error) {
+	return loadList[BannedPlayer](dir, BannedPlayersFile)
+}
+
+// SaveBannedPlayers writes the banned-players.json of a server directory.
+func SaveBannedPlayers(dir string, entries []BannedPlayer) error {
+	return saveList(dir, BannedPlayersFile, entries)
+}
+
+// BanPlayer bans a player, replacing an existing ban of the same UUID.
+func BanPlayer(dir string, player Player, ban Ban) error {
+	if err := player.validate(); err != nil {
+		return err
+	}
+
+	entries, err := LoadBannedPlayers(dir)
+	if err != nil {
+		return err
+	}
+	entries = removeMatching(entries, func(entry BannedPlayer) bool {
+		return matchesPlayer(entry.UUID, entry.Name, player.UUID)
+	})
+	created, source, expires, reason := ban.fields()
+	entries = append(entries, BannedPlayer{
+		UUID:    player.UUID,
+		Name:    player.Name,
+		Created: created,
+		Source:  source,
+		Expires: expires,
+		Reason:  reason,
+	})
+	return SaveBannedPlayers(dir, entries)
+}
+
+// PardonPlayer removes the ban of the player with the name or UUID and reports whether one was removed.
+func PardonPlayer(dir, nameOrUUID string) (bool, error) {
+	return removeFromList(dir, BannedPlayersFile, func(entry BannedPlayer) bool {
+		return matchesPlayer(entry.UUID, entry.Name, nameOrUUID)
+	})
+}
+
+// LoadBannedIPs reads the banned-ips.json of a server directory. A missing file has no entries.
+func LoadBannedIPs(dir string) ([]BannedIP, error) {
+	return loadList[BannedIP](dir, BannedIPsFile)
+}
+
+// SaveBannedIPs writes the banned-ips.json of a server directory.
+func SaveBannedIPs(dir string, entries []BannedIP) error {
+	return saveList(dir, BannedIPsFile, entries)
+}
+
+// BanIP bans an IP address, replacing an existing ban of the address.
+func BanIP(dir, ip string, ban Ban) error {
+	address := net.ParseIP(ip)
+	if address == nil {
+		return fmt.Errorf("invalid IP address %q", ip)
+	}
+	ip = address.String()
+
+	entries, err := LoadBannedIPs(dir)
+	if err != nil {
+		return err
+	}
+	entries = removeMatching(entries, func(entry BannedIP) bool { return entry.IP == ip })
+	created, source, expires, reason := ban.fields()
+	entries = append(entries, BannedIP{IP: ip, Created: created, Source: source, Expires: expires, Reason: reason})
+	return SaveBannedIPs(dir, entries)
+}
+
+// PardonIP removes the ban of an IP address and reports whether one was removed.
+func PardonIP(dir, ip string) (bool, error) {
+	if address := net.ParseIP(ip); address != nil {
+		ip = address.String()
+	}
+	return removeFromList(dir, BannedIPsFile, func(entry BannedIP) bool { return entry.IP == ip })
+}
+
+// validate checks that the player has a UUID and a name.
+func (p Player) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("player has no name")
+	}
+	if _, err := formatUUID(p.UUID); err != nil {
+		return fmt.Errorf("invalid UUID of %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// matchesPlayer reports whether an entry belongs to the player with the name or UUID.
+// Names are compared case-insensitively, as the server does.
+func matchesPlayer(uuid, name, nameOrUUID string) bool {
+	if formatted, err := formatUUID(nameOrUUID); err == nil {
+		return strings.EqualFold(uuid, formatted)
+	}
+	return strings.EqualFold(name, nameOrUUID)
+}
+
+// removeMatching returns the entries that don't match.
+func removeMatching[T any](entries []T, match func(T) bool) []T {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if !match(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// removeFromList removes the matching entries of a list file and reports whether any were removed.
+func removeFromList[T any](dir, name string, match func(T) bool) (bool, error) {
+	entries, err := loadList[T](dir, name)
+	if err != nil {
+		return false, err
+	}
+	count := len(entries)
+	entries = removeMatching(entries, match)
+	if len(entries) == count {
+		return false, nil
+	}
+	return true, saveList(dir, name, entries)
+}
+
+// loadList reads a player list file. A missing or empty file has no entries.
+func loadList[T any](dir, name string) ([]T, error) {
+	path := filepath.Join(dir, name)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	var entries []T
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// saveList writes a player list file, indented like the server writes it.
+func saveList[T any](dir, name string, entries []T) error {
+	if entries == nil {
+		entries = []T{}
+	}
+
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entries); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), content.Bytes(), 0644)
+}
@@ -0,0 +1,122 @@
+package serverconfig
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MojangProfileURL is the Mojang API endpoint that returns the profile of a player name appended to it.
+const MojangProfileURL = "https://api.mojang.com/users/profiles/minecraft/"
+
+// ErrPlayerNotFound is returned by resolvers when no player has the name.
+var ErrPlayerNotFound = errors.New("player not found")
+
+// Player identifies a player in the player list files.
+type Player struct {
+	// UUID is the player's UUID in its dashed form.
+	UUID string
+
+	// Name is the player's name.
+	Name string
+}
+
+// PlayerResolver resolves a player name to the player's UUID.
+type PlayerResolver interface {
+	ResolvePlayer(ctx context.Context, name string) (Player, error)
+}
+
+// OfflineResolver resolves players the way servers in offline mode do, with OfflineUUID.
+type OfflineResolver struct{}
+
+// ResolvePlayer returns the offline-mode UUID of the name.
+func (OfflineResolver) ResolvePlayer(ctx context.Context, name string) (Player, error) {
+	return Player{UUID: OfflineUUID(name), Name: name}, nil
+}
+
+// MojangResolver resolves players with the Mojang profile API, as servers in online mode do.
+type MojangResolver struct {
+	// URL is the profile endpoint the name is appended to. It defaults to MojangProfileURL,
+	// and can point to a local stand-in serving the same responses.
+	URL string
+
+	// Client sends the requests. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// ResolvePlayer looks up the UUID of the name, along with its spelling as registered.
+// It returns ErrPlayerNotFound if no account has the name.
+func (r MojangResolver) ResolvePlayer(ctx context.Context, name string) (Player, error) {
+	baseURL := r.URL
+	if baseURL == "" {
+		baseURL = MojangProfileURL
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	profileURL := baseURL + url.PathEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, profileURL, nil)
+	if err != nil {
+		return Player{}, fmt.Errorf("failed to create request for %s: %w", profileURL, err)
+	}
+	response, err := client.Do(req)
+	if err != nil {
+		return Player{}, fmt.Errorf("failed to fetch the profile of %s: %w", name, err)
+	}
+	defer response.Body.Close()
+
+	// The API answers 204 No Content for unknown names, and 404 in its newer versions
+	if response.StatusCode == http.StatusNoContent || response.StatusCode == http.StatusNotFound {
+		return Player{}, fmt.Errorf("%s: %w", name, ErrPlayerNotFound)
+	}
+	if response.StatusCode != http.StatusOK {
+		return Player{}, fmt.Errorf("unexpected status %d when fetching the profile of %s", response.StatusCode, name)
+	}
+
+	var profile struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&profile); err != nil {
+		return Player{}, fmt.Errorf("failed to decode the profile of %s: %w", name, err)
+	}
+	uuid, err := formatUUID(profile.ID)
+	if err != nil {
+		return Player{}, fmt.Errorf("invalid profile of %s: %w", name, err)
+	}
+	if profile.Name == "" {
+		profile.Name = name
+	}
+	return Player{UUID: uuid, Name: profile.Name}, nil
+}
+
+// OfflineUUID returns the UUID an offline-mode server gives to a player name:
+// the name-based (version 3) UUID of "OfflinePlayer:<name>", as Java's UUID.nameUUIDFromBytes derives it.
+func OfflineUUID(name string) string {
+	sum := md5.Sum([]byte("OfflinePlayer:" + name))
+	sum[6] = sum[6]&0x0f | 0x30 // version 3
+	sum[8] = sum[8]&0x3f | 0x80 // IETF variant
+	return dashUUID(hex.EncodeToString(sum[:]))
+}
+
+// formatUUID returns the dashed form of a UUID written with or without dashes.
+func formatUUID(uuid string) (string, error) {
+	digits := strings.ToLower(strings.ReplaceAll(uuid, "-", ""))
+	if _, err := hex.DecodeString(digits); err != nil || len(digits) != 32 {
+		return "", fmt.Errorf("%q is not a UUID", uuid)
+	}
+	return dashUUID(digits), nil
+}
+
+// dashUUID inserts the dashes into 32 hexadecimal digits.
+func dashUUID(digits string) string {
+	return digits[:8] + "-" + digits[8:12] + "-" + digits[12:16] + "-" + digits[16:20] + "-" + digits[20:]
+}
@@ -0,0 +1,325 @@
+package serverconfig
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Properties is a Java properties file such as server.properties.
+// Comments, blank lines, the order of the keys and the line endings are kept, and entries whose value
+// doesn't change are written back byte for byte, so that saving a loaded file produces a minimal diff.
+type Properties struct {
+	lines []propertyLine
+
+	// newline is the line ending of the file ("\n" or "\r\n").
+	newline string
+
+	// finalNewline reports whether the file ends with a line ending.
+	finalNewline bool
+}
+
+// propertyLine is a logical line of a properties file: a comment, a blank line or an entry.
+type propertyLine struct {
+	// raw holds the natural lines of the logical line as read or generated, without line endings.
+	// Entries continued with a trailing backslash span several natural lines.
+	raw []string
+
+	// isEntry reports whether the line is a key-value entry.
+	isEntry bool
+
+	// key is the unescaped key of an entry.
+	key string
+
+	// value is the unescaped value of an entry.
+	value string
+
+	// prefix is the text of the first natural line before the value (e.g., "motd=" or "  motd = "),
+	// which is kept when the value is replaced.
+	prefix string
+}
+
+// NewProperties returns an empty properties file with the header comment of server.properties.
+func NewProperties() *Properties {
+	return &Properties{
+		lines:        []propertyLine{{raw: []string{"#Minecraft server properties"}}},
+		newline:      "\n",
+		finalNewline: true,
+	}
+}
+
+// LoadProperties reads a properties file.
+func LoadProperties(path string) (*Properties, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseProperties(file)
+}
+
+// ParseProperties parses a properties file from r, following the format read by java.util.Properties#load:
+// comments start with '#' or '!', keys end at the first unescaped '=', ':' or whitespace,
+// and lines ending with an odd number of backslashes continue on the next line.
+func ParseProperties(r io.Reader) (*Properties, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+
+	properties := &Properties{newline: "\n", finalNewline: content == "" || strings.HasSuffix(content, "\n")}
+	if strings.Contains(content, "\r\n") {
+		properties.newline = "\r\n"
+	}
+
+	naturalLines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		naturalLines = nil
+	}
+	for i := range naturalLines {
+		naturalLines[i] = strings.TrimSuffix(naturalLines[i], "\r")
+	}
+
+	for i := 0; i < len(naturalLines); i++ {
+		raw := naturalLines[i]
+		trimmed := strings.TrimLeft(raw, " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			properties.lines = append(properties.lines, propertyLine{raw: []string{raw}})
+			continue
+		}
+
+		// Join the continuation lines into one logical line
+		line := propertyLine{raw: []string{raw}, isEntry: true}
+		logical := trimmed
+		firstLength := -1
+		for continues(logical) && i+1 < len(naturalLines) {
+			logical = logical[:len(logical)-1]
+			if firstLength < 0 {
+				firstLength = len(logical)
+			}
+			i++
+			line.raw = append(line.raw, naturalLines[i])
+			logical += strings.TrimLeft(naturalLines[i], " \t\f")
+		}
+		if continues(logical) {
+			// A continuation at the end of the file is dropped
+			logical = logical[:len(logical)-1]
+		}
+		if firstLength < 0 {
+			firstLength = len(logical)
+		}
+
+		key, valueStart := splitEntry(logical)
+		line.key = unescape(key)
+		line.value = unescape(logical[valueStart:])
+		if valueStart <= firstLength {
+			line.prefix = raw[:len(raw)-len(trimmed)+valueStart]
+		} else {
+			line.prefix = escape(line.key, true) + "="
+		}
+		properties.lines = append(properties.lines, line)
+	}
+
+	return properties, nil
+}
+
+// continues reports whether a line ends with an odd number of backslashes, i.e. continues on the next line.
+func continues(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// splitEntry returns the escaped key of a logical line and the index where its value starts.
+// The key ends at the first unescaped '=', ':' or whitespace, which may be followed by whitespace
+// and at most one '=' or ':'.
+func splitEntry(entry string) (string, int) {
+	keyEnd := len(entry)
+	for i := 0; i < len(entry); i++ {
+		if entry[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", entry[i]) >= 0 {
+			keyEnd = i
+			break
+		}
+	}
+
+	valueStart := keyEnd
+	for valueStart < len(entry) && strings.IndexByte(" \t\f", entry[valueStart]) >= 0 {
+		valueStart++
+	}
+	if valueStart < len(entry) && (entry[valueStart] == '=' || entry[valueStart] == ':') {
+		valueStart++
+	}
+	for valueStart < len(entry) && strings.IndexByte(" \t\f", entry[valueStart]) >= 0 {
+		valueStart++
+	}
+
+	return entry[:keyEnd], valueStart
+}
+
+// find returns the index of the last entry with the key, which is the one Java uses, or -1.
+func (p *Properties) find(key string) int {
+	for i := len(p.lines) - 1; i >= 0; i-- {
+		if p.lines[i].isEntry && p.lines[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get returns the value of a key and whether it is set.
+func (p *Properties) Get(key string) (string, bool) {
+	if i := p.find(key); i >= 0 {
+		return p.lines[i].value, true
+	}
+	return "", false
+}
+
+// Set sets the value of a key without validating it. An existing entry is updated in place, keeping its key
+// and separator as written; setting the current value leaves the entry untouched. A new key is appended.
+func (p *Properties) Set(key, value string) {
+	i := p.find(key)
+	if i < 0 {
+		prefix := escape(key, true) + "="
+		p.lines = append(p.lines, propertyLine{raw: []string{prefix + escape(value, false)}, isEntry: true, key: key, value: value, prefix: prefix})
+		return
+	}
+
+	line := &p.lines[i]
+	if line.value == value {
+		return
+	}
+	line.value = value
+	line.raw = []string{line.prefix + escape(value, false)}
+}
+
+// Delete removes every entry of a key.
+func (p *Properties) Delete(key string) {
+	lines := p.lines[:0]
+	for _, line := range p.lines {
+		if !line.isEntry || line.key != key {
+			lines = append(lines, line)
+		}
+	}
+	p.lines = lines
+}
+
+// Keys returns the keys in file order.
+func (p *Properties) Keys() []string {
+	var keys []string
+	for _, line := range p.lines {
+		if line.isEntry && !slices.Contains(keys, line.key) {
+			keys = append(keys, line.key)
+		}
+	}
+	return keys
+}
+
+// WriteTo writes the properties file to w.
+func (p *Properties) WriteTo(w io.Writer) (int64, error) {
+	var naturalLines []string
+	for _, line := range p.lines {
+		naturalLines = append(naturalLines, line.raw...)
+	}
+
+	content := strings.Join(naturalLines, p.newline)
+	if p.finalNewline && len(naturalLines) > 0 {
+		content += p.newline
+	}
+
+	n, err := io.WriteString(w, content)
+	return int64(n), err
+}
+
+// Save writes the properties file to path.
+func (p *Properties) Save(path string) error {
+	var content strings.Builder
+	if _, err := p.WriteTo(&content); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content.String()), 0644)
+}
+
+// escape escapes a key or value the way java.util.Properties#store does.
+// Characters outside of printable ASCII are written as \uXXXX escapes, which every server version reads correctly.
+func escape(s string, isKey bool) string {
+	var builder strings.Builder
+	for i, r := range s {
+		switch {
+		case r == ' ' && (isKey || i == 0):
+			builder.WriteString(`\ `)
+		case r == '\\':
+			builder.WriteString(`\\`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\f':
+			builder.WriteString(`\f`)
+		case r == '=' || r == ':' || r == '#' || r == '!':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&builder, `\u%04X`, unit)
+			}
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// unescape resolves the escapes of a key or value, including \uXXXX escapes of UTF-16 surrogate pairs.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var units []uint16
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '\\' || i+1 == len(runes) {
+			units = append(units, utf16.Encode([]rune{r})...)
+			continue
+		}
+
+		i++
+		switch runes[i] {
+		case 't':
+			units = append(units, '\t')
+		case 'n':
+			units = append(units, '\n')
+		case 'r':
+			units = append(units, '\r')
+		case 'f':
+			units = append(units, '\f')
+		case 'u':
+			if i+4 < len(runes) {
+				if unit, err := strconv.ParseUint(string(runes[i+1:i+5]), 16, 16); err == nil {
+					units = append(units, uint16(unit))
+					i += 4
+					continue
+				}
+			}
+			units = append(units, 'u')
+		default:
+			units = append(units, utf16.Encode([]rune{runes[i]})...)
+		}
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,229 @@
+package serverconfig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNotSet is returned by the typed getters for unknown keys that are not set.
+var ErrNotSet = errors.New("property not set")
+
+// Difficulty is the value of the "difficulty" property.
+type Difficulty string
+
+const (
+	Peaceful Difficulty = "peaceful"
+	Easy     Difficulty = "easy"
+	Normal   Difficulty = "normal"
+	Hard     Difficulty = "hard"
+)
+
+// Gamemode is the value of the "gamemode" property.
+type Gamemode string
+
+const (
+	Survival  Gamemode = "survival"
+	Creative  Gamemode = "creative"
+	Adventure Gamemode = "adventure"
+	Spectator Gamemode = "spectator"
+)
+
+// propertyKind is the value type of a known property.
+type propertyKind int
+
+const (
+	kindString propertyKind = iota
+	kindInt
+	kindBool
+	kindEnum
+)
+
+// propertySchema describes the value of a known property.
+type propertySchema struct {
+	kind propertyKind
+
+	// defaultValue is the value the server uses when the key is missing.
+	defaultValue string
+
+	// min and max bound the values of kindInt properties.
+	min, max int
+
+	// values lists the names of kindEnum properties. Their index is the legacy numeric value that older servers wrote.
+	values []string
+}
+
+func intProperty(defaultValue, min, max int) propertySchema {
+	return propertySchema{kind: kindInt, defaultValue: strconv.Itoa(defaultValue), min: min, max: max}
+}
+
+func boolProperty(defaultValue bool) propertySchema {
+	return propertySchema{kind: kindBool, defaultValue: strconv.FormatBool(defaultValue)}
+}
+
+func stringProperty(defaultValue string) propertySchema {
+	return propertySchema{kind: kindString, defaultValue: defaultValue}
+}
+
+func enumProperty(defaultValue string, values ...string) propertySchema {
+	return propertySchema{kind: kindEnum, defaultValue: defaultValue, values: values}
+}
+
+// knownProperties describes the properties of the vanilla server.properties.
+var knownProperties = map[string]propertySchema{
+	"accepts-transfers":                 boolProperty(false),
+	"allow-flight":                      boolProperty(false),
+	"allow-nether":                      boolProperty(true),
+	"broadcast-console-to-ops":          boolProperty(true),
+	"broadcast-rcon-to-ops":             boolProperty(true),
+	"difficulty":                        enumProperty(string(Easy), string(Peaceful), string(Easy), string(Normal), string(Hard)),
+	"enable-command-block":              boolProperty(false),
+	"enable-jmx-monitoring":             boolProperty(false),
+	"enable-query":                      boolProperty(false),
+	"enable-rcon":                       boolProperty(false),
+	"enable-status":                     boolProperty(true),
+	"enforce-secure-profile":            boolProperty(true),
+	"enforce-whitelist":                 boolProperty(false),
+	"entity-broadcast-range-percentage": intProperty(100, 10, 1000),
+	"force-gamemode":                    boolProperty(false),
+	"function-permission-level":         intProperty(2, 1, 4),
+	"gamemode":                          enumProperty(string(Survival), string(Survival), string(Creative), string(Adventure), string(Spectator)),
+	"generate-structures":               boolProperty(true),
+	"hardcore":                          boolProperty(false),
+	"hide-online-players":               boolProperty(false),
+	"level-name":                        stringProperty("world"),
+	"level-seed":                        stringProperty(""),
+	"log-ips":                           boolProperty(true),
+	"max-chained-neighbor-updates":      intProperty(1000000, math.MinInt32, math.MaxInt32),
+	"max-players":                       intProperty(20, 0, math.MaxInt32),
+	"max-tick-time":                     intProperty(60000, -1, math.MaxInt32),
+	"max-world-size":                    intProperty(29999984, 1, 29999984),
+	"motd":                              stringProperty("A Minecraft Server"),
+	"network-compression-threshold":     intProperty(256, -1, math.MaxInt32),
+	"online-mode":                       boolProperty(true),
+	"op-permission-level":               intProperty(4, 0, 4),
+	"player-idle-timeout":               intProperty(0, 0, math.MaxInt32),
+	"prevent-proxy-connections":         boolProperty(false),
+	"pvp":                               boolProperty(true),
+	"query.port":                        intProperty(25565, 1, 65535),
+	"rate-limit":                        intProperty(0, 0, math.MaxInt32),
+	"rcon.password":                     stringProperty(""),
+	"rcon.port":                         intProperty(25575, 1, 65535),
+	"require-resource-pack":             boolProperty(false),
+	"server-ip":                         stringProperty(""),
+	"server-port":                       intProperty(25565, 1, 65535),
+	"simulation-distance":               intProperty(10, 3, 32),
+	"spawn-monsters":                    boolProperty(true),
+	"spawn-protection":                  intProperty(16, 0, math.MaxInt32),
+	"sync-chunk-writes":                 boolProperty(true),
+	"use-native-transport":              boolProperty(true),
+	"view-distance":                     intProperty(10, 3, 32),
+	"white-list":                        boolProperty(false),
+}
+
+// ValidateProperty checks a value against the type and range of a known property.
+// Values of unknown keys are always valid.
+func ValidateProperty(key, value string) error {
+	schema, ok := knownProperties[key]
+	if !ok {
+		return nil
+	}
+
+	switch schema.kind {
+	case kindInt:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %q is not a number", key, value)
+		}
+		if number < schema.min || number > schema.max {
+			return fmt.Errorf("invalid %s: %d is out of range %d-%d", key, number, schema.min, schema.max)
+		}
+	case kindBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("invalid %s: %q is not true or false", key, value)
+		}
+	case kindEnum:
+		if _, err := enumValue(schema, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// enumValue returns the name of an enum value, resolving the legacy numeric values (e.g., "2" for "normal").
+func enumValue(schema propertySchema, value string) (string, error) {
+	if slices.Contains(schema.values, strings.ToLower(value)) {
+		return strings.ToLower(value), nil
+	}
+	if index, err := strconv.Atoi(value); err == nil && index >= 0 && index < len(schema.values) {
+		return schema.values[index], nil
+	}
+	return "", fmt.Errorf("%q is not one of %s", value, strings.Join(schema.values, ", "))
+}
+
+// lookup returns the value of a key, or the server's default for known keys that are not set.
+func (p *Properties) lookup(key string) (string, error) {
+	if value, ok := p.Get(key); ok {
+		return value, nil
+	}
+	if schema, ok := knownProperties[key]; ok {
+		return schema.defaultValue, nil
+	}
+	return "", fmt.Errorf("%s: %w", key, ErrNotSet)
+}
+
+// GetInt returns the value of a key as an integer. Known keys that are not set return the server's default.
+func (p *Properties) GetInt(key string) (int, error) {
+	value, err := p.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q is not a number", key, value)
+	}
+	return number, nil
+}
+
+// SetInt sets an integer value after validating it against the range of known keys.
+func (p *Properties) SetInt(key string, value int) error {
+	return p.SetString(key, strconv.Itoa(value))
+}
+
+// GetBool returns the value of a key as a boolean. Known keys that are not set return the server's default.
+func (p *Properties) GetBool(key string) (bool, error) {
+	value, err := p.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid %s: %q is not true or false", key, value)
+	}
+}
+
+// SetBool sets a boolean value after checking that known keys are booleans.
+func (p *Properties) SetBool(key string, value bool) error {
+	return p.SetString(key, strconv.FormatBool(value))
+}
+
+// GetString returns the value of a key. Known keys that are not set return the server's default.
+func (p *Properties) GetString(key string) (string, error) {
+	return p.lookup(key)
+}
+
+// SetString sets a value after validating it with ValidateProperty.
+func (p *Properties) SetString(key, value string) error {
+	if err := ValidateProperty(key, value); err != nil {
+		return err
+	}
+	p.Set(key, value)
+	return nil
+}
@@ -0,0 +1,362 @@
+package serverconfig_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/serverconfig"
+)
+
+func TestAcceptEULA(t *testing.T) {
+	dir := t.TempDir()
+
+	accepted, err := serverconfig.EULAAccepted(dir)
+	if err != nil || accepted {
+		t.Fatalf("expected the EULA not to be accepted, got %v (%v)", accepted, err)
+	}
+
+	now := time.Date(2024, time.October, 15, 12, 30, 0, 0, time.UTC)
+	if err := serverconfig.AcceptEULA(dir, now); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "eula.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "#Tue Oct 15 12:30:00 UTC 2024\neula=true\n") {
+		t.Errorf("unexpected eula.txt:\n%s", data)
+	}
+
+	accepted, err = serverconfig.EULAAccepted(dir)
+	if err != nil || !accepted {
+		t.Errorf("expected the EULA to be accepted, got %v (%v)", accepted, err)
+	}
+}
+
+func TestProperties(t *testing.T) {
+	const original = "#Minecraft server properties\n#Tue Oct 15 12:30:00 UTC 2024\nenable-jmx-monitoring=false\nmotd=A Minecraft Server\nlevel-seed=\nserver-port=25565\n"
+
+	properties, err := serverconfig.ParseProperties(strings.NewReader(original))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if value, ok := properties.Get("motd"); !ok || value != "A Minecraft Server" {
+		t.Errorf("unexpected motd %q (%v)", value, ok)
+	}
+	if value, ok := properties.Get("level-seed"); !ok || value != "" {
+		t.Errorf("unexpected level-seed %q (%v)", value, ok)
+	}
+
+	properties.Set("motd", "Café \U0001F600 = fun")
+	properties.Set("max-players", "10")
+
+	var output strings.Builder
+	if _, err := properties.WriteTo(&output); err != nil {
+		t.Fatal(err)
+	}
+	expected := "#Minecraft server properties\n#Tue Oct 15 12:30:00 UTC 2024\nenable-jmx-monitoring=false\n" +
+		`motd=Caf\u00E9 \uD83D\uDE00 \= fun` + "\nlevel-seed=\nserver-port=25565\nmax-players=10\n"
+	if output.String() != expected {
+		t.Errorf("unexpected output:\n%s", output.String())
+	}
+
+	// The escaped value reads back as the original text
+	reparsed, err := serverconfig.ParseProperties(strings.NewReader(output.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := reparsed.Get("motd"); value != "Café \U0001F600 = fun" {
+		t.Errorf("unexpected reparsed motd %q", value)
+	}
+}
+
+func TestWriteServerProperties(t *testing.T) {
+	port := 25570
+	motd := "Welcome"
+	onlineMode := false
+
+	t.Run("new file from template", func(t *testing.T) {
+		dir := t.TempDir()
+		templatePath := filepath.Join(t.TempDir(), "template.properties")
+		if err := os.WriteFile(templatePath, []byte("# Our defaults\ndifficulty=hard\nmotd=Template\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := serverconfig.WriteServerProperties(dir, templatePath, serverconfig.Settings{ServerPort: &port, MOTD: &motd})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "server.properties"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "# Our defaults\ndifficulty=hard\nmotd=Welcome\nserver-port=25570\n" {
+			t.Errorf("unexpected server.properties:\n%s", data)
+		}
+	})
+
+	t.Run("existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "server.properties")
+		if err := os.WriteFile(path, []byte("#Minecraft server properties\nonline-mode=true\nview-distance=10\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := serverconfig.WriteServerProperties(dir, "", serverconfig.Settings{
+			OnlineMode: &onlineMode,
+			Extra:      map[string]string{"allow-flight": "true"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "#Minecraft server properties\nonline-mode=false\nview-distance=10\nallow-flight=true\n" {
+			t.Errorf("unexpected server.properties:\n%s", data)
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		dir := t.TempDir()
+		invalidPort := 0
+
+		err := serverconfig.WriteServerProperties(dir, "", serverconfig.Settings{ServerPort: &invalidPort})
+		if err == nil {
+			t.Fatal("expected an error for port 0")
+		}
+		if _, err := os.Stat(filepath.Join(dir, "server.properties")); !os.IsNotExist(err) {
+			t.Errorf("expected no server.properties to be written, got %v", err)
+		}
+	})
+}
+
+func TestPropertiesMinimalDiff(t *testing.T) {
+	const original = "# Settings\r\nmotd = Hello \\\r\n    World\r\n! legacy comment\r\nserver-port : 25565\r\ndifficulty=2\r\nlevel-name=world"
+
+	properties, err := serverconfig.ParseProperties(strings.NewReader(original))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if value, _ := properties.Get("motd"); value != "Hello World" {
+		t.Errorf("unexpected continued motd %q", value)
+	}
+
+	// Setting the current values changes nothing
+	properties.Set("motd", "Hello World")
+	if err := properties.SetServerPort(25565); err != nil {
+		t.Fatal(err)
+	}
+	var output strings.Builder
+	if _, err := properties.WriteTo(&output); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != original {
+		t.Errorf("expected an unchanged file, got:\n%q", output.String())
+	}
+
+	// A changed value keeps the key and separator as written, and the CRLF line endings
+	if err := properties.SetServerPort(25570); err != nil {
+		t.Fatal(err)
+	}
+	properties.Delete("level-name")
+	output.Reset()
+	if _, err := properties.WriteTo(&output); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# Settings\r\nmotd = Hello \\\r\n    World\r\n! legacy comment\r\nserver-port : 25570\r\ndifficulty=2"
+	if output.String() != expected {
+		t.Errorf("unexpected output:\n%q", output.String())
+	}
+}
+
+func TestPropertiesTyped(t *testing.T) {
+	properties, err := serverconfig.ParseProperties(strings.NewReader("difficulty=2\ngamemode=Creative\nview-distance=12\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if difficulty, err := properties.Difficulty(); err != nil || difficulty != serverconfig.Normal {
+		t.Errorf("expected the legacy difficulty to be normal, got %q (%v)", difficulty, err)
+	}
+	if gamemode, err := properties.Gamemode(); err != nil || gamemode != serverconfig.Creative {
+		t.Errorf("unexpected gamemode %q (%v)", gamemode, err)
+	}
+	if distance, err := properties.ViewDistance(); err != nil || distance != 12 {
+		t.Errorf("unexpected view distance %d (%v)", distance, err)
+	}
+
+	// Missing known keys return the server's defaults
+	if port, err := properties.ServerPort(); err != nil || port != 25565 {
+		t.Errorf("expected the default port, got %d (%v)", port, err)
+	}
+	if onlineMode, err := properties.OnlineMode(); err != nil || !onlineMode {
+		t.Errorf("expected online mode by default, got %v (%v)", onlineMode, err)
+	}
+	if _, err := properties.GetString("custom-key"); !errors.Is(err, serverconfig.ErrNotSet) {
+		t.Errorf("expected ErrNotSet for an unknown key, got %v", err)
+	}
+
+	invalid := []func() error{
+		func() error { return properties.SetServerPort(70000) },
+		func() error { return properties.SetViewDistance(64) },
+		func() error { return properties.SetDifficulty("insane") },
+		func() error { return properties.SetString("online-mode", "yes") },
+	}
+	for i, set := range invalid {
+		if err := set(); err == nil {
+			t.Errorf("expected invalid value %d to be rejected", i)
+		}
+	}
+	if value, _ := properties.Get("difficulty"); value != "2" {
+		t.Errorf("expected an invalid value not to be set, got %q", value)
+	}
+}
+
+func TestOfflineUUID(t *testing.T) {
+	if uuid := serverconfig.OfflineUUID("Notch"); uuid != "b50ad385-829d-3141-a216-7e7d7539ba7f" {
+		t.Errorf("unexpected offline UUID %s", uuid)
+	}
+}
+
+func TestMojangResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/profiles/notch":
+			fmt.Fprint(w, `{"id":"069a79f444e94726a5befca90e38aaf5","name":"Notch"}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	resolver := serverconfig.MojangResolver{URL: server.URL + "/profiles/"}
+
+	player, err := resolver.ResolvePlayer(context.Background(), "notch")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if player != (serverconfig.Player{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch"}) {
+		t.Errorf("unexpected player %+v", player)
+	}
+
+	if _, err := resolver.ResolvePlayer(context.Background(), "nobody"); !errors.Is(err, serverconfig.ErrPlayerNotFound) {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+}
+
+func TestPlayerLists(t *testing.T) {
+	dir := t.TempDir()
+	notch := serverconfig.Player{UUID: "069a79f4-44e9-4726-a5be-fca90e38aaf5", Name: "Notch"}
+	jeb := serverconfig.Player{UUID: serverconfig.OfflineUUID("jeb_"), Name: "jeb_"}
+
+	t.Run("ops", func(t *testing.T) {
+		if err := serverconfig.AddOp(dir, notch, 2, false); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		// Adding again updates the level instead of duplicating the entry
+		if err := serverconfig.AddOp(dir, notch, 4, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := serverconfig.AddOp(dir, jeb, 5, false); err == nil {
+			t.Error("expected op level 5 to be rejected")
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, serverconfig.OpsFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "[\n  {\n    \"uuid\": \"069a79f4-44e9-4726-a5be-fca90e38aaf5\",\n    \"name\": \"Notch\",\n" +
+			"    \"level\": 4,\n    \"bypassesPlayerLimit\": true\n  }\n]\n"
+		if string(data) != expected {
+			t.Errorf("unexpected ops.json:\n%s", data)
+		}
+
+		removed, err := serverconfig.RemoveOp(dir, "notch")
+		if err != nil || !removed {
+			t.Fatalf("expected Notch to be removed, got %v (%v)", removed, err)
+		}
+		data, _ = os.ReadFile(filepath.Join(dir, serverconfig.OpsFile))
+		if string(data) != "[]\n" {
+			t.Errorf("expected an empty ops.json, got:\n%s", data)
+		}
+	})
+
+	t.Run("whitelist", func(t *testing.T) {
+		for _, player := range []serverconfig.Player{notch, jeb} {
+			if err := serverconfig.AddToWhitelist(dir, player); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		}
+		removed, err := serverconfig.RemoveFromWhitelist(dir, notch.UUID)
+		if err != nil || !removed {
+			t.Fatalf("expected Notch to be removed, got %v (%v)", removed, err)
+		}
+
+		entries, err := serverconfig.LoadWhitelist(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0] != (serverconfig.WhitelistEntry{UUID: jeb.UUID, Name: jeb.Name}) {
+			t.Errorf("unexpected whitelist %+v", entries)
+		}
+	})
+
+	t.Run("bans", func(t *testing.T) {
+		created := time.Date(2024, time.October, 15, 12, 30, 0, 0, time.UTC)
+		if err := serverconfig.BanPlayer(dir, jeb, serverconfig.Ban{Created: created}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := serverconfig.BanIP(dir, "192.168.0.10", serverconfig.Ban{Created: created, Reason: "Spam & abuse"}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if err := serverconfig.BanIP(dir, "not-an-ip", serverconfig.Ban{}); err == nil {
+			t.Error("expected an invalid IP address to be rejected")
+		}
+
+		players, err := serverconfig.LoadBannedPlayers(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := serverconfig.BannedPlayer{
+			UUID:    jeb.UUID,
+			Name:    "jeb_",
+			Created: "2024-10-15 12:30:00 +0000",
+			Source:  serverconfig.DefaultBanSource,
+			Expires: "forever",
+			Reason:  serverconfig.DefaultBanReason,
+		}
+		if len(players) != 1 || players[0] != expected {
+			t.Errorf("unexpected banned players %+v", players)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, serverconfig.BannedIPsFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), `"reason": "Spam & abuse"`) {
+			t.Errorf("unexpected banned-ips.json:\n%s", data)
+		}
+
+		if removed, err := serverconfig.PardonIP(dir, "192.168.0.10"); err != nil || !removed {
+			t.Errorf("expected the IP to be pardoned, got %v (%v)", removed, err)
+		}
+		if removed, err := serverconfig.PardonPlayer(dir, "Steve"); err != nil || removed {
+			t.Errorf("expected nothing to be pardoned, got %v (%v)", removed, err)
+		}
+	})
+}
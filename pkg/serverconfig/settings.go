@@ -0,0 +1,154 @@
+package serverconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Settings holds commonly configured server.properties values.
+// Nil fields are left unchanged when the settings are applied.
+type Settings struct {
+	// ServerPort is the TCP port the server listens on ("server-port").
+	ServerPort *int
+
+	// MOTD is the message shown in the server list ("motd").
+	MOTD *string
+
+	// OnlineMode enables the authentication of players with Mojang ("online-mode").
+	OnlineMode *bool
+
+	// ViewDistance is the view distance in chunks ("view-distance").
+	ViewDistance *int
+
+	// SimulationDistance is the distance in chunks in which the world is ticked ("simulation-distance").
+	SimulationDistance *int
+
+	// MaxPlayers is the maximum number of players ("max-players").
+	MaxPlayers *int
+
+	// Difficulty is the difficulty (e.g., "easy", "hard") ("difficulty").
+	Difficulty *string
+
+	// Gamemode is the default game mode (e.g., "survival", "creative") ("gamemode").
+	Gamemode *string
+
+	// LevelName is the name of the world directory ("level-name").
+	LevelName *string
+
+	// LevelSeed is the seed used to generate the world ("level-seed").
+	LevelSeed *string
+
+	// PVP enables players fighting each other ("pvp").
+	PVP *bool
+
+	// WhiteList enables the whitelist ("white-list").
+	WhiteList *bool
+
+	// Extra holds further keys to set, for properties without a field.
+	Extra map[string]string
+}
+
+// Apply sets the non-nil settings in the properties file.
+// It returns an error if a value is invalid, e.g. a port out of range or an unknown difficulty.
+func (s Settings) Apply(properties *Properties) error {
+	var errs []error
+	setInt := func(set func(int) error, value *int) {
+		if value != nil {
+			errs = append(errs, set(*value))
+		}
+	}
+	setBool := func(set func(bool) error, value *bool) {
+		if value != nil {
+			errs = append(errs, set(*value))
+		}
+	}
+	setString := func(set func(string) error, value *string) {
+		if value != nil {
+			errs = append(errs, set(*value))
+		}
+	}
+
+	setInt(properties.SetServerPort, s.ServerPort)
+	setString(properties.SetMOTD, s.MOTD)
+	setBool(properties.SetOnlineMode, s.OnlineMode)
+	setInt(properties.SetViewDistance, s.ViewDistance)
+	setInt(properties.SetSimulationDistance, s.SimulationDistance)
+	setInt(properties.SetMaxPlayers, s.MaxPlayers)
+	if s.Difficulty != nil {
+		errs = append(errs, properties.SetDifficulty(Difficulty(*s.Difficulty)))
+	}
+	if s.Gamemode != nil {
+		errs = append(errs, properties.SetGamemode(Gamemode(*s.Gamemode)))
+	}
+	setString(properties.SetLevelName, s.LevelName)
+	setString(properties.SetLevelSeed, s.LevelSeed)
+	setBool(properties.SetPVP, s.PVP)
+	setBool(properties.SetWhiteList, s.WhiteList)
+
+	keys := make([]string, 0, len(s.Extra))
+	for key := range s.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		errs = append(errs, properties.SetString(key, s.Extra[key]))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the settings without applying them to a file.
+func (s Settings) Validate() error {
+	return s.Apply(NewProperties())
+}
+
+// WriteServerProperties seeds the server.properties of a server directory.
+// The file is based on the existing server.properties, or on the template if there is none; when both exist,
+// the template's values are set in the existing file. The settings are applied last.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - templatePath: an optional properties file with the initial values. It may be empty.
+//   - settings: the values to set.
+//
+// Returns:
+//   - error: an error if a file cannot be read or written, or if a value is invalid.
+func WriteServerProperties(dir, templatePath string, settings Settings) error {
+	path := filepath.Join(dir, "server.properties")
+
+	properties, err := LoadProperties(path)
+	if os.IsNotExist(err) {
+		properties, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if templatePath != "" {
+		template, err := LoadProperties(templatePath)
+		if err != nil {
+			return err
+		}
+		if properties == nil {
+			properties = template
+		} else {
+			for _, key := range template.Keys() {
+				value, _ := template.Get(key)
+				if err := properties.SetString(key, value); err != nil {
+					return fmt.Errorf("%s: %w", templatePath, err)
+				}
+			}
+		}
+	}
+	if properties == nil {
+		properties = NewProperties()
+	}
+
+	if err := settings.Apply(properties); err != nil {
+		return err
+	}
+	return properties.Save(path)
+}
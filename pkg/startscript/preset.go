@@ -0,0 +1,115 @@
+package startscript
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PresetNone adds no garbage collector flags.
+	PresetNone = "none"
+
+	// PresetAikar adds Aikar's G1 flags (https://mcflags.emc.gs), tuned for Minecraft servers.
+	PresetAikar = "aikar"
+
+	// PresetZGC uses the Z garbage collector, which keeps pauses short on large heaps.
+	PresetZGC = "zgc"
+)
+
+// Presets returns the names of the available flag presets.
+func Presets() []string {
+	return []string{PresetNone, PresetAikar, PresetZGC}
+}
+
+// memoryPattern matches a Java memory size (e.g., "4G", "2048M").
+var memoryPattern = regexp.MustCompile(`^(\d+)([KkMmGg]?)$`)
+
+// aikarFlags are Aikar's G1 flags for heaps below 12 GB.
+var aikarFlags = []string{
+	"-XX:+UseG1GC",
+	"-XX:+ParallelRefProcEnabled",
+	"-XX:MaxGCPauseMillis=200",
+	"-XX:+UnlockExperimentalVMOptions",
+	"-XX:+DisableExplicitGC",
+	"-XX:+AlwaysPreTouch",
+	"-XX:G1NewSizePercent=30",
+	"-XX:G1MaxNewSizePercent=40",
+	"-XX:G1HeapRegionSize=8M",
+	"-XX:G1ReservePercent=20",
+	"-XX:G1HeapWastePercent=5",
+	"-XX:G1MixedGCCountTarget=4",
+	"-XX:InitiatingHeapOccupancyPercent=15",
+	"-XX:G1MixedGCLiveThresholdPercent=90",
+	"-XX:G1RSetUpdatingPauseTimePercent=5",
+	"-XX:SurvivorRatio=32",
+	"-XX:+PerfDisableSharedMem",
+	"-XX:MaxTenuringThreshold=1",
+	"-Dusing.aikars.flags=https://mcflags.emc.gs",
+	"-Daikars.new.flags=true",
+}
+
+// aikarLargeHeapFlags replace some of aikarFlags for heaps of 12 GB and more.
+var aikarLargeHeapFlags = map[string]string{
+	"-XX:G1NewSizePercent=30":               "-XX:G1NewSizePercent=40",
+	"-XX:G1MaxNewSizePercent=40":            "-XX:G1MaxNewSizePercent=50",
+	"-XX:G1HeapRegionSize=8M":               "-XX:G1HeapRegionSize=16M",
+	"-XX:G1ReservePercent=20":               "-XX:G1ReservePercent=15",
+	"-XX:InitiatingHeapOccupancyPercent=15": "-XX:InitiatingHeapOccupancyPercent=20",
+}
+
+// zgcFlags enable the Z garbage collector.
+var zgcFlags = []string{
+	"-XX:+UseZGC",
+	"-XX:+AlwaysPreTouch",
+	"-XX:+DisableExplicitGC",
+	"-XX:+PerfDisableSharedMem",
+}
+
+// jvmFlags returns the memory and preset flags for the given options.
+func jvmFlags(memory, preset string) ([]string, error) {
+	var flags []string
+
+	heapMB := 0
+	if memory != "" {
+		match := memoryPattern.FindStringSubmatch(memory)
+		if match == nil {
+			return nil, fmt.Errorf("invalid memory size: %s (expected e.g. 4G or 2048M)", memory)
+		}
+		heapMB = memoryMB(match[1], match[2])
+		flags = append(flags, "-Xms"+memory, "-Xmx"+memory)
+	}
+
+	switch preset {
+	case "", PresetNone:
+	case PresetAikar:
+		for _, flag := range aikarFlags {
+			if replacement, ok := aikarLargeHeapFlags[flag]; ok && heapMB >= 12*1024 {
+				flag = replacement
+			}
+			flags = append(flags, flag)
+		}
+	case PresetZGC:
+		flags = append(flags, zgcFlags...)
+	default:
+		return nil, fmt.Errorf("unknown flag preset: %s (expected %s)", preset, strings.Join(Presets(), ", "))
+	}
+
+	return flags, nil
+}
+
+// memoryMB converts a memory size to megabytes. A size without unit is in bytes.
+func memoryMB(amount, unit string) int {
+	value, _ := strconv.Atoi(amount)
+	switch strings.ToUpper(unit) {
+	case "G":
+		return value * 1024
+	case "M":
+		return value
+	case "K":
+		return value / 1024
+	default:
+		return value / (1024 * 1024)
+	}
+}
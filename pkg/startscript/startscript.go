@@ -0,0 +1,128 @@
+package startscript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options configures the generated start scripts.
+type Options struct {
+	// Memory is the heap size used for both -Xms and -Xmx (e.g., "4G", "2048M"). If empty, Java's default is used.
+	Memory string
+
+	// Preset selects the garbage collector flags: PresetNone (default), PresetAikar or PresetZGC.
+	Preset string
+
+	// Restart makes the scripts start the server again after it crashed, i.e. exited with a non-zero status.
+	Restart bool
+
+	// Java is the Java command used by start.sh (e.g., "./runtime/bin/java"). Defaults to "java".
+	Java string
+
+	// JavaWindows is the Java command used by start.bat (e.g., `runtime\bin\java.exe`). Defaults to "java".
+	JavaWindows string
+}
+
+// Validate checks the memory size and the flag preset.
+func (o Options) Validate() error {
+	_, err := jvmFlags(o.Memory, o.Preset)
+	return err
+}
+
+// Write generates start.sh and start.bat in installDir for the server installed there.
+// The server is launched as determined by DetectTarget, with the memory and preset flags before the
+// launch arguments and "nogui" after them; arguments passed to the scripts are forwarded to the server.
+//
+// Parameters:
+//   - installDir: the server directory.
+//   - options: the script options.
+//
+// Returns:
+//   - error: an error if the options are invalid, the server cannot be detected or the scripts cannot be written.
+func Write(installDir string, options Options) error {
+	flags, err := jvmFlags(options.Memory, options.Preset)
+	if err != nil {
+		return err
+	}
+
+	target, err := DetectTarget(installDir)
+	if err != nil {
+		return err
+	}
+
+	java := options.Java
+	if java == "" {
+		java = "java"
+	}
+	javaWindows := options.JavaWindows
+	if javaWindows == "" {
+		javaWindows = "java"
+	}
+
+	unixCommand := commandLine(java, flags, target.Unix)
+	if err := os.WriteFile(filepath.Join(installDir, "start.sh"), []byte(unixScript(unixCommand, options.Restart)), 0755); err != nil {
+		return err
+	}
+
+	windowsCommand := commandLine(javaWindows, flags, target.Windows)
+	if err := os.WriteFile(filepath.Join(installDir, "start.bat"), []byte(windowsScript(windowsCommand, options.Restart)), 0755); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// commandLine joins the Java command, the JVM flags and the launch arguments, followed by "nogui".
+func commandLine(java string, flags, args []string) string {
+	parts := append([]string{java}, flags...)
+	parts = append(parts, args...)
+	parts = append(parts, "nogui")
+	return strings.Join(parts, " ")
+}
+
+// unixScript generates start.sh, which runs from its own directory.
+func unixScript(command string, restart bool) string {
+	var script strings.Builder
+	script.WriteString("#!/usr/bin/env sh\n")
+	script.WriteString("cd \"$(dirname \"$0\")\" || exit 1\n")
+
+	if !restart {
+		fmt.Fprintf(&script, "exec %s \"$@\"\n", command)
+		return script.String()
+	}
+
+	script.WriteString("while true; do\n")
+	fmt.Fprintf(&script, "  %s \"$@\"\n", command)
+	script.WriteString("  status=$?\n")
+	script.WriteString("  if [ \"$status\" -eq 0 ]; then\n")
+	script.WriteString("    break\n")
+	script.WriteString("  fi\n")
+	script.WriteString("  echo \"Server exited with status $status, restarting in 5 seconds (press Ctrl+C to stop)...\"\n")
+	script.WriteString("  sleep 5\n")
+	script.WriteString("done\n")
+	return script.String()
+}
+
+// windowsScript generates start.bat, which runs from its own directory.
+func windowsScript(command string, restart bool) string {
+	lines := []string{"@echo off", `cd /d "%~dp0"`}
+
+	if !restart {
+		lines = append(lines, command+" %*", "pause")
+		return strings.Join(lines, "\r\n") + "\r\n"
+	}
+
+	lines = append(lines,
+		":start",
+		command+" %*",
+		"if %errorlevel% equ 0 goto end",
+		"echo Server exited with status %errorlevel%, restarting in 5 seconds (press Ctrl+C to stop)...",
+		"timeout /t 5 /nobreak >nul",
+		"goto start",
+		":end",
+		"pause",
+	)
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
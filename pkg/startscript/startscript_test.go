@@ -0,0 +1,166 @@
+package startscript_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/startscript"
+)
+
+// writeFiles creates files with the given contents in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDetectTarget(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   map[string]string
+		unix    []string
+		windows []string
+		wantErr error
+	}{
+		{
+			name:    "server jar",
+			files:   map[string]string{"server.jar": ""},
+			unix:    []string{"-jar", "server.jar"},
+			windows: []string{"-jar", "server.jar"},
+		},
+		{
+			name:    "extracted bundler",
+			files:   map[string]string{"server_args.txt": "-cp\nversions/1.21.6/server-1.21.6.jar\nnet.minecraft.bundler.Main\n"},
+			unix:    []string{"@server_args.txt"},
+			windows: []string{"@server_args.txt"},
+		},
+		{
+			name: "forge run scripts",
+			files: map[string]string{
+				"run.sh":  "#!/usr/bin/env sh\n# Add custom JVM arguments to user_jvm_args.txt\njava @user_jvm_args.txt @libraries/net/minecraftforge/forge/1.20.1-47.2.0/unix_args.txt \"$@\"\n",
+				"run.bat": "@echo off\r\njava @user_jvm_args.txt @libraries/net/minecraftforge/forge/1.20.1-47.2.0/win_args.txt %*\r\npause\r\n",
+			},
+			unix:    []string{"@user_jvm_args.txt", "@libraries/net/minecraftforge/forge/1.20.1-47.2.0/unix_args.txt"},
+			windows: []string{"@user_jvm_args.txt", "@libraries/net/minecraftforge/forge/1.20.1-47.2.0/win_args.txt"},
+		},
+		{
+			name:    "forge shim launcher",
+			files:   map[string]string{"run.sh": "#!/usr/bin/env sh\njava @user_jvm_args.txt -jar forge-1.21.6-56.0.0-shim.jar \"$@\"\n"},
+			unix:    []string{"@user_jvm_args.txt", "-jar", "forge-1.21.6-56.0.0-shim.jar"},
+			windows: []string{"@user_jvm_args.txt", "-jar", "forge-1.21.6-56.0.0-shim.jar"},
+		},
+		{
+			name:    "legacy forge jar",
+			files:   map[string]string{"forge-1.12.2-14.23.5.2860.jar": "", "forge-1.12.2-14.23.5.999.jar": "", "minecraft_server.1.12.2.jar": ""},
+			unix:    []string{"-jar", "forge-1.12.2-14.23.5.2860.jar"},
+			windows: []string{"-jar", "forge-1.12.2-14.23.5.2860.jar"},
+		},
+		{
+			name:    "installer only",
+			files:   map[string]string{"installer.jar": ""},
+			wantErr: startscript.ErrInstallerNotRun,
+		},
+		{
+			name:    "empty",
+			files:   map[string]string{},
+			wantErr: startscript.ErrNoServer,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.files)
+
+			target, err := startscript.DetectTarget(dir)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got: %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if !slices.Equal(target.Unix, tc.unix) || !slices.Equal(target.Windows, tc.windows) {
+				t.Errorf("unexpected target: %+v", target)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("restart loop with aikar flags", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"server.jar": ""})
+
+		err := startscript.Write(dir, startscript.Options{Memory: "4G", Preset: startscript.PresetAikar, Restart: true, Java: "./runtime/bin/java"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		unix, err := os.ReadFile(filepath.Join(dir, "start.sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"./runtime/bin/java -Xms4G -Xmx4G -XX:+UseG1GC",
+			"-XX:G1HeapRegionSize=8M",
+			"-jar server.jar nogui \"$@\"",
+			"while true; do",
+		} {
+			if !strings.Contains(string(unix), expected) {
+				t.Errorf("start.sh does not contain %q:\n%s", expected, unix)
+			}
+		}
+
+		windows, err := os.ReadFile(filepath.Join(dir, "start.bat"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"java -Xms4G -Xmx4G -XX:+UseG1GC",
+			"-jar server.jar nogui %*",
+			"goto start",
+		} {
+			if !strings.Contains(string(windows), expected) {
+				t.Errorf("start.bat does not contain %q:\n%s", expected, windows)
+			}
+		}
+	})
+
+	t.Run("large heap aikar flags", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"server.jar": ""})
+
+		if err := startscript.Write(dir, startscript.Options{Memory: "16384M", Preset: startscript.PresetAikar}); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		unix, err := os.ReadFile(filepath.Join(dir, "start.sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(unix), "-XX:G1HeapRegionSize=16M") || strings.Contains(string(unix), "while true") {
+			t.Errorf("unexpected start.sh:\n%s", unix)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"server.jar": ""})
+
+		if err := startscript.Write(dir, startscript.Options{Memory: "4 GB"}); err == nil {
+			t.Error("expected error for an invalid memory size, got nil")
+		}
+		if err := startscript.Write(dir, startscript.Options{Preset: "shenandoah"}); err == nil {
+			t.Error("expected error for an unknown preset, got nil")
+		}
+	})
+}
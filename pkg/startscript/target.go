@@ -0,0 +1,147 @@
+package startscript
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/detect"
+)
+
+// ErrNoServer is returned by DetectTarget if the directory contains no server to start.
+var ErrNoServer = errors.New("no server found to start")
+
+// ErrInstallerNotRun is returned by DetectTarget if the directory only contains a Forge or NeoForge installer.
+var ErrInstallerNotRun = errors.New("the server installer has not been run yet")
+
+// Target holds the arguments that launch the server, following the JVM flags.
+// The arguments differ between the platforms for servers started through platform-specific arguments files.
+type Target struct {
+	// Unix holds the arguments used by start.sh (e.g., "-jar", "server.jar").
+	Unix []string
+
+	// Windows holds the arguments used by start.bat.
+	Windows []string
+}
+
+// DetectTarget determines how the server installed in installDir is launched:
+//   - Forge and NeoForge servers are launched like their run.sh and run.bat, with user_jvm_args.txt and the arguments files.
+//   - Vanilla servers with an extracted bundler are launched with server_args.txt.
+//   - Servers with a server.jar are launched with it (the server, or the loader's launch jar for Fabric).
+//   - Forge servers of Minecraft 1.16 and older are launched with their Forge jar (e.g., "forge-1.12.2-14.23.5.2860.jar").
+//
+// Parameters:
+//   - installDir: the server directory.
+//
+// Returns:
+//   - Target: the launch arguments.
+//   - error: ErrInstallerNotRun if only an installer was downloaded, ErrNoServer if there is no server, or a read error.
+func DetectTarget(installDir string) (Target, error) {
+	unixArgs, err := runScriptArgs(filepath.Join(installDir, "run.sh"))
+	if err != nil {
+		return Target{}, err
+	}
+	windowsArgs, err := runScriptArgs(filepath.Join(installDir, "run.bat"))
+	if err != nil {
+		return Target{}, err
+	}
+	if unixArgs != nil || windowsArgs != nil {
+		if unixArgs == nil {
+			unixArgs = windowsArgs
+		}
+		if windowsArgs == nil {
+			windowsArgs = unixArgs
+		}
+		return Target{Unix: unixArgs, Windows: windowsArgs}, nil
+	}
+
+	if exists(filepath.Join(installDir, "server_args.txt")) {
+		args := []string{"@server_args.txt"}
+		return Target{Unix: args, Windows: args}, nil
+	}
+
+	if exists(filepath.Join(installDir, "server.jar")) {
+		args := []string{"-jar", "server.jar"}
+		return Target{Unix: args, Windows: args}, nil
+	}
+
+	forgeJar, err := findForgeJar(installDir)
+	if err != nil {
+		return Target{}, err
+	}
+	if forgeJar != "" {
+		args := []string{"-jar", forgeJar}
+		return Target{Unix: args, Windows: args}, nil
+	}
+
+	if exists(filepath.Join(installDir, "installer.jar")) {
+		return Target{}, ErrInstallerNotRun
+	}
+	return Target{}, ErrNoServer
+}
+
+// runScriptArgs reads the launch arguments from a run script written by the Forge or NeoForge installer
+// (e.g., `java @user_jvm_args.txt @libraries/.../unix_args.txt "$@"`). It returns nil if the script doesn't exist
+// or doesn't use user_jvm_args.txt.
+func runScriptArgs(scriptPath string) ([]string, error) {
+	file, err := os.Open(scriptPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for i, field := range fields {
+			if field != "@user_jvm_args.txt" {
+				continue
+			}
+
+			args := []string{field}
+			for _, arg := range fields[i+1:] {
+				// Drop the forwarded script arguments, the generated scripts forward them on their own
+				if arg == `"$@"` || arg == "$@" || arg == "%*" {
+					continue
+				}
+				args = append(args, arg)
+			}
+			return args, nil
+		}
+	}
+	return nil, scanner.Err()
+}
+
+// findForgeJar returns the name of the Forge jar left by an installer of Minecraft 1.16 or older, or an empty string
+// if there is none. If several are left, e.g. by an earlier install, the newest version is returned.
+func findForgeJar(installDir string) (string, error) {
+	entries, err := os.ReadDir(installDir)
+	if err != nil {
+		return "", err
+	}
+
+	var forgeJar, newestGame, newestForge string
+	for _, entry := range entries {
+		gameVersion, forgeVersion, ok := detect.ParseForgeJar(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+		order := internal.CompareVersions(gameVersion, newestGame)
+		if forgeJar == "" || order > 0 || order == 0 && internal.CompareVersions(forgeVersion, newestForge) > 0 {
+			forgeJar, newestGame, newestForge = entry.Name(), gameVersion, forgeVersion
+		}
+	}
+	return forgeJar, nil
+}
+
+// exists reports whether a file exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
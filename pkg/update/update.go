@@ -0,0 +1,171 @@
+// Package update replaces the files of an installed server with those of a newer install,
+// backing up what it replaces and keeping the files the user changed.
+package update
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+)
+
+// Options configures Apply.
+type Options struct {
+	// Previous is the lock of the installed server, or nil if the server was not installed with one.
+	// Files it records are replaced and removed only if they still have their locked digest.
+	Previous *lockfile.Lock
+
+	// Replace lists further files that may be replaced, relative to the server directory with forward slashes.
+	// Without a lock, these are the only existing files that are replaced (e.g., the detected server jar).
+	Replace []string
+
+	// BackupDir is the directory the replaced and removed files are moved to.
+	BackupDir string
+}
+
+// InstallerScripts are the run scripts written by the Forge and NeoForge installers. They launch a specific version
+// through its arguments files, so an update without a lock replaces them along with the detected server.
+var InstallerScripts = []string{"run.sh", "run.bat"}
+
+// Result lists the files of an update, relative to the server directory with forward slashes.
+type Result struct {
+	// Added are the new files.
+	Added []string
+
+	// Replaced are the files replaced with their new version. Their old version is in the backup directory.
+	Replaced []string
+
+	// Unchanged are the files whose new version is identical.
+	Unchanged []string
+
+	// Kept are the existing files that differ from the new version but were left alone,
+	// because the user changed them or they are not known to be part of the install.
+	Kept []string
+
+	// Removed are the files of the previous install that the new one no longer has.
+	// They were moved to the backup directory.
+	Removed []string
+}
+
+// Installed returns the files of the new install that are in place: the added, replaced and unchanged files.
+func (r *Result) Installed() []string {
+	installed := slices.Concat(r.Added, r.Replaced, r.Unchanged)
+	slices.Sort(installed)
+	return installed
+}
+
+// rename is a file move performed by Apply, kept to undo it if a later move fails.
+type rename struct {
+	from, to string
+}
+
+// Apply moves the files of a new install from a staging directory into the server directory.
+// The old versions of replaced and removed files are moved to the backup directory first. Every file is
+// moved with a rename, so each one is replaced atomically; if a move fails, the moves done so far are undone.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - stagingDir: the directory the new install was downloaded to. It should be on the same file system as dir.
+//   - options: the previous install and the backup directory.
+//
+// Returns:
+//   - *Result: the files of the update.
+//   - error: an error if a file cannot be read or moved.
+func Apply(dir, stagingDir string, options Options) (*Result, error) {
+	staged, err := lockfile.TakeSnapshot(stagingDir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(staged))
+	for path := range staged {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	locked := map[string]string{}
+	if options.Previous != nil {
+		for _, artifact := range options.Previous.Artifacts {
+			locked[artifact.Path] = artifact.SHA256
+		}
+	}
+
+	result := &Result{}
+	for _, path := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		current, err := internal.HashFile(target, "sha256")
+		if os.IsNotExist(err) {
+			result.Added = append(result.Added, path)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := internal.HashFile(filepath.Join(stagingDir, filepath.FromSlash(path)), "sha256")
+		if err != nil {
+			return nil, err
+		}
+		switch digest, ok := locked[path]; {
+		case current == next:
+			result.Unchanged = append(result.Unchanged, path)
+		case ok && current == digest, slices.Contains(options.Replace, path):
+			result.Replaced = append(result.Replaced, path)
+		default:
+			result.Kept = append(result.Kept, path)
+		}
+	}
+
+	if options.Previous != nil {
+		for _, artifact := range options.Previous.Artifacts {
+			// The provisioned Java runtime is not part of the download, although older locks record its files
+			if _, ok := staged[artifact.Path]; ok || strings.HasPrefix(artifact.Path, lockfile.RuntimeDir+"/") {
+				continue
+			}
+			current, err := internal.HashFile(filepath.Join(dir, filepath.FromSlash(artifact.Path)), "sha256")
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if current == artifact.SHA256 {
+				result.Removed = append(result.Removed, artifact.Path)
+			} else {
+				result.Kept = append(result.Kept, artifact.Path)
+			}
+		}
+	}
+
+	// Move the old files out of the way, then the new files into place
+	var moves []rename
+	for _, path := range slices.Concat(result.Replaced, result.Removed) {
+		moves = append(moves, rename{from: filepath.Join(dir, filepath.FromSlash(path)), to: filepath.Join(options.BackupDir, filepath.FromSlash(path))})
+	}
+	for _, path := range slices.Concat(result.Added, result.Replaced) {
+		moves = append(moves, rename{from: filepath.Join(stagingDir, filepath.FromSlash(path)), to: filepath.Join(dir, filepath.FromSlash(path))})
+	}
+
+	for i, move := range moves {
+		err := os.MkdirAll(filepath.Dir(move.to), 0755)
+		if err == nil {
+			err = os.Rename(move.from, move.to)
+		}
+		if err != nil {
+			undo(moves[:i])
+			return nil, fmt.Errorf("failed to move %s to %s: %w", move.from, move.to, err)
+		}
+	}
+
+	return result, nil
+}
+
+// undo reverts moves in reverse order. Failures are ignored, since the original error is more relevant.
+func undo(moves []rename) {
+	for i := len(moves) - 1; i >= 0; i-- {
+		os.Rename(moves[i].to, moves[i].from)
+	}
+}
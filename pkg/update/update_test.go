@@ -0,0 +1,176 @@
+package update_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+	"github.com/abulleDev/mcserverdl/v2/pkg/update"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"server.jar":             "old server",
+		"libraries/old.jar":      "old library",
+		"libraries/same.jar":     "same library",
+		"server.properties":      "motd=Default",
+		"world/level.dat":        "world",
+		"libraries/modified.jar": "original",
+	})
+	previous, err := lockfile.HashArtifacts(dir, []string{"server.jar", "libraries/old.jar", "libraries/same.jar", "server.properties", "libraries/modified.jar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The user changes the properties and a library after the install
+	writeFiles(t, dir, map[string]string{"server.properties": "motd=Mine", "libraries/modified.jar": "patched"})
+
+	stagingDir := filepath.Join(dir, ".staging")
+	writeFiles(t, stagingDir, map[string]string{
+		"server.jar":         "new server",
+		"libraries/new.jar":  "new library",
+		"libraries/same.jar": "same library",
+		"server.properties":  "motd=New default",
+	})
+
+	backupDir := filepath.Join(dir, ".backup")
+	result, err := update.Apply(dir, stagingDir, update.Options{Previous: &lockfile.Lock{Artifacts: previous}, BackupDir: backupDir})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	expected := &update.Result{
+		Added:     []string{"libraries/new.jar"},
+		Replaced:  []string{"server.jar"},
+		Unchanged: []string{"libraries/same.jar"},
+		Kept:      []string{"server.properties", "libraries/modified.jar"},
+		Removed:   []string{"libraries/old.jar"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if installed := result.Installed(); !reflect.DeepEqual(installed, []string{"libraries/new.jar", "libraries/same.jar", "server.jar"}) {
+		t.Errorf("unexpected installed files %v", installed)
+	}
+
+	for path, content := range map[string]string{
+		"server.jar":                "new server",
+		"libraries/new.jar":         "new library",
+		"server.properties":         "motd=Mine",
+		"world/level.dat":           "world",
+		".backup/server.jar":        "old server",
+		".backup/libraries/old.jar": "old library",
+	} {
+		if actual := readFile(t, filepath.Join(dir, path)); actual != content {
+			t.Errorf("expected %s to be %q, got %q", path, content, actual)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "libraries", "old.jar")); !os.IsNotExist(err) {
+		t.Errorf("expected the removed library to be gone, got %v", err)
+	}
+}
+
+func TestApplyKeepsRuntime(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"run.sh":              "runtime/bin/java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.100/unix_args.txt",
+		"runtime/bin/java":    "java",
+		"runtime/lib/modules": "modules",
+	})
+	previous, err := lockfile.HashArtifacts(dir, []string{"run.sh", "runtime/bin/java", "runtime/lib/modules"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stagingDir := filepath.Join(dir, ".staging")
+	writeFiles(t, stagingDir, map[string]string{
+		"run.sh": "java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.120/unix_args.txt",
+	})
+
+	result, err := update.Apply(dir, stagingDir, update.Options{Previous: &lockfile.Lock{Artifacts: previous}, BackupDir: filepath.Join(dir, ".backup")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(result.Replaced, []string{"run.sh"}) || len(result.Removed) != 0 || len(result.Kept) != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	for _, path := range []string{"runtime/bin/java", "runtime/lib/modules"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+			t.Errorf("expected %s to stay in place, got %v", path, err)
+		}
+	}
+}
+
+func TestApplyWithoutLock(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"server.jar": "old server", "eula.txt": "eula=true"})
+
+	stagingDir := filepath.Join(dir, ".staging")
+	writeFiles(t, stagingDir, map[string]string{"server.jar": "new server", "eula.txt": "eula=false"})
+
+	result, err := update.Apply(dir, stagingDir, update.Options{Replace: []string{"server.jar"}, BackupDir: filepath.Join(dir, ".backup")})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !reflect.DeepEqual(result.Replaced, []string{"server.jar"}) || !reflect.DeepEqual(result.Kept, []string{"eula.txt"}) {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if content := readFile(t, filepath.Join(dir, "eula.txt")); content != "eula=true" {
+		t.Errorf("expected eula.txt to be kept, got %q", content)
+	}
+
+	t.Run("installer layout", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"run.sh":            "java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.100/unix_args.txt",
+			"run.bat":           "java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.100/win_args.txt",
+			"user_jvm_args.txt": "-Xmx4G",
+			"libraries/net/neoforged/neoforge/21.4.100/unix_args.txt": "old",
+		})
+
+		stagingDir := filepath.Join(dir, ".staging")
+		writeFiles(t, stagingDir, map[string]string{
+			"run.sh":            "java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.120/unix_args.txt",
+			"run.bat":           "java @user_jvm_args.txt @libraries/net/neoforged/neoforge/21.4.120/win_args.txt",
+			"user_jvm_args.txt": "# Add custom JVM arguments here",
+			"libraries/net/neoforged/neoforge/21.4.120/unix_args.txt": "new",
+		})
+
+		result, err := update.Apply(dir, stagingDir, update.Options{Replace: update.InstallerScripts, BackupDir: filepath.Join(dir, ".backup")})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !reflect.DeepEqual(result.Replaced, []string{"run.bat", "run.sh"}) || !reflect.DeepEqual(result.Kept, []string{"user_jvm_args.txt"}) ||
+			!reflect.DeepEqual(result.Added, []string{"libraries/net/neoforged/neoforge/21.4.120/unix_args.txt"}) {
+			t.Errorf("unexpected result %+v", result)
+		}
+		if content := readFile(t, filepath.Join(dir, "run.sh")); !strings.Contains(content, "21.4.120") {
+			t.Errorf("expected run.sh to start the new version, got %q", content)
+		}
+	})
+}
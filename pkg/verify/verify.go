@@ -0,0 +1,317 @@
+// Package verify checks the files of an installed server against the digests they are expected to have,
+// from the install's lock file, the lists inside bundler and Paperclip jars, the checksum files of Maven repositories,
+// or upstream checksums.
+package verify
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/abulleDev/mcserverdl/v2/internal"
+	"github.com/abulleDev/mcserverdl/v2/internal/maven"
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+)
+
+// Expected is a file of a server directory with the digest it should have.
+type Expected struct {
+	// Path is the path of the file relative to the server directory, with forward slashes.
+	Path string
+
+	// Algorithm is the hash algorithm of the digest ("md5", "sha1", "sha256" or "sha512").
+	Algorithm string
+
+	// Digest is the hex-encoded digest of the file.
+	Digest string
+
+	// Source describes where the digest comes from (e.g., "lock" or "upstream sha1").
+	Source string
+
+	// Optional reports whether the file may be absent, e.g. a library the server only extracts on its first start.
+	Optional bool
+}
+
+// Kind is the kind of an Issue.
+type Kind int
+
+const (
+	// Missing is an expected file that doesn't exist.
+	Missing Kind = iota
+
+	// Modified is a file whose digest differs from the expected one.
+	Modified
+
+	// Extra is a file in a directory of installed files that no source expects.
+	Extra
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Missing:
+		return "missing"
+	case Modified:
+		return "modified"
+	case Extra:
+		return "extra"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Issue is a file that doesn't match what is expected.
+type Issue struct {
+	// Kind is the kind of the issue.
+	Kind Kind
+
+	// Expected is the expected file. Only Path is set for extra files.
+	Expected
+
+	// Actual is the hex-encoded digest of a modified file.
+	Actual string
+}
+
+func (i Issue) String() string {
+	if i.Kind == Modified {
+		return fmt.Sprintf("%s: %s (expected %s %s from %s, got %s)", i.Path, i.Kind, i.Algorithm, i.Digest, i.Source, i.Actual)
+	}
+	return i.Path + ": " + i.Kind.String()
+}
+
+// Report is the result of Check.
+type Report struct {
+	// Verified are the files that match every digest expected of them, sorted.
+	Verified []string
+
+	// Issues are the missing, modified and extra files, sorted by path.
+	Issues []Issue
+}
+
+// Failed reports whether a file is missing or modified. Extra files only count if strict is set.
+func (r *Report) Failed(strict bool) bool {
+	for _, issue := range r.Issues {
+		if issue.Kind != Extra || strict {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtraDirs are the directories Check searches for extra files: the directories installers and bundlers fill.
+var ExtraDirs = []string{"libraries", "versions"}
+
+// Check verifies the files of a server directory. A file may be expected several times with different digests
+// (e.g., by the lock and by an upstream checksum); it is modified if any of them doesn't match.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - expected: the expected files.
+//   - extras: whether to report the files in ExtraDirs and the jars at the top level that are not expected.
+//
+// Returns:
+//   - *Report: the verified files and the issues.
+//   - error: an error if a file exists but cannot be read.
+func Check(dir string, expected []Expected, extras bool) (*Report, error) {
+	report := &Report{}
+	failed := map[string]bool{}
+	checked := map[string]bool{}
+	for _, file := range expected {
+		checked[file.Path] = true
+		if failed[file.Path] {
+			continue
+		}
+
+		actual, err := internal.HashFile(filepath.Join(dir, filepath.FromSlash(file.Path)), file.Algorithm)
+		if os.IsNotExist(err) {
+			if !file.Optional {
+				report.Issues = append(report.Issues, Issue{Kind: Missing, Expected: file})
+				failed[file.Path] = true
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(actual, file.Digest) {
+			report.Issues = append(report.Issues, Issue{Kind: Modified, Expected: file, Actual: actual})
+			failed[file.Path] = true
+		}
+	}
+
+	for path := range checked {
+		if !failed[path] && fileExists(filepath.Join(dir, filepath.FromSlash(path))) {
+			report.Verified = append(report.Verified, path)
+		}
+	}
+	slices.Sort(report.Verified)
+
+	if extras {
+		unexpected, err := findExtras(dir, checked)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range unexpected {
+			report.Issues = append(report.Issues, Issue{Kind: Extra, Expected: Expected{Path: path}})
+		}
+	}
+	slices.SortStableFunc(report.Issues, func(a, b Issue) int { return strings.Compare(a.Path, b.Path) })
+
+	return report, nil
+}
+
+// findExtras lists the files in ExtraDirs and the jars at the top level of dir that are not in known.
+func findExtras(dir string, known map[string]bool) ([]string, error) {
+	var extras []string
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".jar") && !known[entry.Name()] {
+			extras = append(extras, entry.Name())
+		}
+	}
+
+	for _, extraDir := range ExtraDirs {
+		err := filepath.WalkDir(filepath.Join(dir, extraDir), func(fullPath string, entry fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			relativePath, err := filepath.Rel(dir, fullPath)
+			if err != nil {
+				return err
+			}
+			if relativePath = filepath.ToSlash(relativePath); !known[relativePath] {
+				extras = append(extras, relativePath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return extras, nil
+}
+
+// FromLock returns the artifacts of a lock as expected files.
+func FromLock(lock *lockfile.Lock) []Expected {
+	expected := make([]Expected, 0, len(lock.Artifacts))
+	for _, artifact := range lock.Artifacts {
+		expected = append(expected, Expected{Path: artifact.Path, Algorithm: "sha256", Digest: artifact.SHA256, Source: "lock"})
+	}
+	return expected
+}
+
+// FromBundler returns the files listed in "META-INF/versions.list" and "META-INF/libraries.list" of a Mojang bundler
+// or Paperclip jar, which are extracted into "versions/" and "libraries/". Paperclip extracts them on the first start,
+// so the files of a list are only required once one of them exists.
+//
+// Parameters:
+//   - dir: the server directory.
+//   - jar: the path of the jar relative to dir, with forward slashes.
+//
+// Returns:
+//   - []Expected: the listed files, or nil if the jar has no lists.
+//   - error: an error if the jar cannot be read or a list is invalid.
+func FromBundler(dir, jar string) ([]Expected, error) {
+	archive, err := zip.OpenReader(filepath.Join(dir, filepath.FromSlash(jar)))
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+	files := internal.ZipEntries(&archive.Reader)
+
+	var expected []Expected
+	for _, kind := range []string{"versions", "libraries"} {
+		name := "META-INF/" + kind + ".list"
+		if _, ok := files[name]; !ok {
+			continue
+		}
+		entries, err := internal.ReadBundlerList(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var listed []Expected
+		extracted := false
+		for _, entry := range entries {
+			cleanPath := path.Clean(entry.Path)
+			if path.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+				return nil, fmt.Errorf("illegal path in %s: %s", name, entry.Path)
+			}
+			relativePath := kind + "/" + cleanPath
+			extracted = extracted || fileExists(filepath.Join(dir, filepath.FromSlash(relativePath)))
+			listed = append(listed, Expected{Path: relativePath, Algorithm: "sha256", Digest: entry.SHA256, Source: jar})
+		}
+		for i := range listed {
+			listed[i].Optional = !extracted
+		}
+		expected = append(expected, listed...)
+	}
+
+	return expected, nil
+}
+
+// FromMaven returns the jars in "libraries/" with the digests of the ".sha256" or ".sha1" files the Maven repositories
+// publish next to them. The library path below "libraries/" is its repository path, as installers lay it out.
+// Jars no repository has (e.g., the outputs of Forge's processors) are left out.
+//
+// Parameters:
+//   - ctx: the context to control the requests.
+//   - dir: the server directory.
+//   - repositories: the base URLs of the repositories, in the order they are tried.
+//
+// Returns:
+//   - []Expected: the libraries with a published checksum.
+//   - error: an error if "libraries/" cannot be read or a checksum cannot be fetched.
+func FromMaven(ctx context.Context, dir string, repositories []string) ([]Expected, error) {
+	librariesDir := filepath.Join(dir, "libraries")
+	var expected []Expected
+	err := filepath.WalkDir(librariesDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && filePath == librariesDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), ".jar") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(librariesDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		for _, repository := range repositories {
+			algorithm, digest, err := maven.FetchChecksum(ctx, strings.TrimSuffix(repository, "/")+"/"+relPath)
+			if errors.Is(err, maven.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			expected = append(expected, Expected{Path: "libraries/" + relPath, Algorithm: algorithm, Digest: digest, Source: "maven " + algorithm})
+			break
+		}
+		return nil
+	})
+	return expected, err
+}
+
+// fileExists reports whether a regular file exists at path.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
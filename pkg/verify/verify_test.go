@@ -0,0 +1,235 @@
+package verify_test
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/abulleDev/mcserverdl/v2/pkg/lockfile"
+	"github.com/abulleDev/mcserverdl/v2/pkg/verify"
+)
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createJar writes a jar with the given entries.
+func createJar(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// issues summarizes the issues of a report as "kind path" strings.
+func issues(report *verify.Report) []string {
+	var summary []string
+	for _, issue := range report.Issues {
+		summary = append(summary, issue.Kind.String()+" "+issue.Path)
+	}
+	return summary
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "server.jar"), "server")
+	writeFile(t, filepath.Join(dir, "libraries", "a.jar"), "a")
+	writeFile(t, filepath.Join(dir, "libraries", "b.jar"), "b")
+	writeFile(t, filepath.Join(dir, "server.properties"), "motd=Hello\n")
+
+	artifacts, err := lockfile.HashArtifacts(dir, []string{"server.jar", "libraries/a.jar", "libraries/b.jar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := verify.FromLock(&lockfile.Lock{Artifacts: artifacts})
+
+	t.Run("intact", func(t *testing.T) {
+		report, err := verify.Check(dir, expected, true)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.Failed(true) || len(report.Issues) != 0 {
+			t.Errorf("unexpected issues %v", issues(report))
+		}
+		if !reflect.DeepEqual(report.Verified, []string{"libraries/a.jar", "libraries/b.jar", "server.jar"}) {
+			t.Errorf("unexpected verified files %v", report.Verified)
+		}
+	})
+
+	t.Run("upstream mismatch", func(t *testing.T) {
+		upstream := verify.Expected{Path: "server.jar", Algorithm: "sha1", Digest: "0000000000000000000000000000000000000000", Source: "upstream sha1"}
+		report, err := verify.Check(dir, append(expected, upstream), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(issues(report), []string{"modified server.jar"}) || report.Issues[0].Source != "upstream sha1" {
+			t.Errorf("unexpected issues %+v", report.Issues)
+		}
+	})
+
+	writeFile(t, filepath.Join(dir, "server.jar"), "tampered")
+	if err := os.Remove(filepath.Join(dir, "libraries", "a.jar")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "libraries", "c.jar"), "c")
+	writeFile(t, filepath.Join(dir, "other.jar"), "other")
+
+	t.Run("tampered", func(t *testing.T) {
+		report, err := verify.Check(dir, expected, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"missing libraries/a.jar", "extra libraries/c.jar", "extra other.jar", "modified server.jar"}
+		if !reflect.DeepEqual(issues(report), want) {
+			t.Errorf("expected issues %v, got %v", want, issues(report))
+		}
+		if report.Issues[3].Actual != sha256Hex("tampered") {
+			t.Errorf("unexpected actual digest %s", report.Issues[3].Actual)
+		}
+		if !reflect.DeepEqual(report.Verified, []string{"libraries/b.jar"}) {
+			t.Errorf("unexpected verified files %v", report.Verified)
+		}
+	})
+
+	t.Run("extra files only", func(t *testing.T) {
+		report, err := verify.Check(dir, expected[1:2], true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Failed(false) || !report.Failed(true) {
+			t.Errorf("expected extra files to fail only in strict mode, got %v", issues(report))
+		}
+	})
+}
+
+func TestFromBundler(t *testing.T) {
+	dir := t.TempDir()
+	createJar(t, filepath.Join(dir, "server.jar"), map[string]string{
+		"META-INF/versions.list":  sha256Hex("paper") + "\t1.21.4\t1.21.4/paper-1.21.4.jar\n",
+		"META-INF/libraries.list": sha256Hex("a") + "\torg.example:a:1.0\torg/example/a.jar\n" + sha256Hex("b") + "\torg.example:b:1.0\torg/example/b.jar\n",
+	})
+
+	// Before the first start, nothing is extracted and nothing is required
+	expected, err := verify.FromBundler(dir, "server.jar")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(expected) != 3 || expected[0].Path != "versions/1.21.4/paper-1.21.4.jar" || expected[0].Source != "server.jar" {
+		t.Fatalf("unexpected expected files %+v", expected)
+	}
+	report, err := verify.Check(dir, expected, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("expected no issues before extraction, got %v", issues(report))
+	}
+
+	// Once a library is extracted, the others of its list must be there too
+	writeFile(t, filepath.Join(dir, "libraries", "org", "example", "a.jar"), "a")
+	expected, err = verify.FromBundler(dir, "server.jar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err = verify.Check(dir, expected, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(issues(report), []string{"missing libraries/org/example/b.jar"}) {
+		t.Errorf("unexpected issues %v", issues(report))
+	}
+
+	// Plain jars have no lists
+	createJar(t, filepath.Join(dir, "plain.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: net.minecraft.server.MinecraftServer\n"})
+	if expected, err := verify.FromBundler(dir, "plain.jar"); err != nil || expected != nil {
+		t.Errorf("expected no files, got %v (%v)", expected, err)
+	}
+}
+
+func TestFromMaven(t *testing.T) {
+	// The first repository only has the SHA-1 of one library, the second the SHA-256 of another
+	forge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/net/minecraftforge/fmlcore/1.0/fmlcore-1.0.jar.sha1" {
+			sum := sha1.Sum([]byte("fmlcore"))
+			fmt.Fprint(w, hex.EncodeToString(sum[:]))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer forge.Close()
+	central := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/org/example/a/1.0/a-1.0.jar.sha256" {
+			fmt.Fprint(w, sha256Hex("a")+"  a-1.0.jar\n")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer central.Close()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "libraries", "net", "minecraftforge", "fmlcore", "1.0", "fmlcore-1.0.jar"), "fmlcore")
+	writeFile(t, filepath.Join(dir, "libraries", "org", "example", "a", "1.0", "a-1.0.jar"), "modified")
+	writeFile(t, filepath.Join(dir, "libraries", "net", "minecraftforge", "forge", "1.0", "forge-1.0-server.jar"), "processed")
+	writeFile(t, filepath.Join(dir, "libraries", "net", "minecraftforge", "forge", "1.0", "unix_args.txt"), "args")
+
+	expected, err := verify.FromMaven(context.Background(), dir, []string{forge.URL, central.URL})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var sources []string
+	for _, file := range expected {
+		sources = append(sources, file.Path+" "+file.Source)
+	}
+	if !reflect.DeepEqual(sources, []string{"libraries/net/minecraftforge/fmlcore/1.0/fmlcore-1.0.jar maven sha1", "libraries/org/example/a/1.0/a-1.0.jar maven sha256"}) {
+		t.Fatalf("unexpected expected files %v", sources)
+	}
+
+	report, err := verify.Check(dir, expected, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(issues(report), []string{"modified libraries/org/example/a/1.0/a-1.0.jar"}) {
+		t.Errorf("unexpected issues %v", issues(report))
+	}
+
+	// A server without libraries has nothing to check
+	if expected, err := verify.FromMaven(context.Background(), t.TempDir(), []string{forge.URL}); err != nil || expected != nil {
+		t.Errorf("expected no files, got %v (%v)", expected, err)
+	}
+}